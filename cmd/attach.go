@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachWorkItemCmd = &cobra.Command{
+		Use:   "attach <Work Item ID> <File, ...>",
+		Short: "Attach files to work item",
+		Long:  "Upload files and link them to the work item as attachments.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			err = attachWorkItemCommand(cmd.Context(), workItemID, args[1:])
+			cobra.CheckErr(err)
+		},
+	}
+
+	attachmentsWorkItemCmd = &cobra.Command{
+		Use:   "attachments <Work Item ID>",
+		Short: "List and download attachments of work item",
+		Long:  "List attachments of the work item, download them into the directory with --download.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			err = attachmentsWorkItemCommand(cmd.Context(), workItemID)
+			cobra.CheckErr(err)
+		},
+	}
+
+	attachWorkItemCmdFlagComment          string
+	attachmentsWorkItemCmdFlagDownloadDir string
+)
+
+func init() {
+	rootCmd.AddCommand(attachWorkItemCmd)
+	rootCmd.AddCommand(attachmentsWorkItemCmd)
+
+	attachWorkItemCmd.Flags().StringVarP(&attachWorkItemCmdFlagComment, "comment", "c", "", "Comment of the attachments")
+	attachmentsWorkItemCmd.Flags().StringVarP(&attachmentsWorkItemCmdFlagDownloadDir, "download", "d", "", "Directory to download attachments into")
+}
+
+// progressReader reports the read bytes to the progressbar in kilobytes.
+type progressReader struct {
+	reader      io.Reader
+	progressbar *pterm.ProgressbarPrinter
+	read        int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if r.progressbar != nil && n > 0 {
+		reported := r.read / 1024
+		r.read += int64(n)
+		if delta := int(r.read/1024 - reported); delta > 0 {
+			r.progressbar.Add(delta)
+		}
+	}
+	return n, err
+}
+
+func newProgressReader(reader io.Reader, title string, size int64) *progressReader {
+	progressbar, _ := pterm.DefaultProgressbar.
+		WithTitle(fmt.Sprintf("%s (KB)", cutString(title, 30, false))).
+		WithTotal(max(int(size/1024), 1)).
+		WithRemoveWhenDone().
+		Start()
+	return &progressReader{reader: reader, progressbar: progressbar}
+}
+
+func (r *progressReader) stop() {
+	if r.progressbar != nil {
+		_, _ = r.progressbar.Stop()
+	}
+}
+
+func attachWorkItemCommand(ctx context.Context, workItemID int, files []string) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	var relations []*workitem.Relation
+	for _, file := range files {
+		url, err := uploadAttachment(ctx, a, file)
+		if err != nil {
+			pterm.Error.Println(fmt.Sprintf("NOT UPLOADED %s: %s", file, err.Error()))
+			continue
+		}
+
+		relations = append(relations, &workitem.Relation{
+			URL:     url,
+			Type:    workitem.AttachedFileRelation,
+			Comment: attachWorkItemCmdFlagComment,
+		})
+		pterm.Success.Println(fmt.Sprintf("UPLOADED %s", file))
+	}
+
+	if len(relations) == 0 {
+		return fmt.Errorf("no files attached to %d", workItemID)
+	}
+
+	_, err = a.WiClient.AddRelations(ctx, workItemID, relations...)
+	if err != nil {
+		return fmt.Errorf("files uploaded, but not attached to %d: %w", workItemID, err)
+	}
+
+	pterm.Success.Println(fmt.Sprintf("ATTACHED %d files to %d", len(relations), workItemID))
+	if len(relations) < len(files) {
+		return fmt.Errorf("%d files not attached", len(files)-len(relations))
+	}
+	return nil
+}
+
+func uploadAttachment(ctx context.Context, api *tfs.API, file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	reader := newProgressReader(f, filepath.Base(file), info.Size())
+	attachment, err := api.WiClient.UploadAttachment(ctx, filepath.Base(file), reader)
+	reader.stop()
+	if err != nil {
+		return "", err
+	}
+
+	return *attachment.Url, nil
+}
+
+func attachmentsWorkItemCommand(ctx context.Context, workItemID int) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	wi, err := a.WiClient.GetExpanded(ctx, workItemID)
+	if err != nil {
+		return err
+	}
+
+	attachments := workitem.GetAttachments(wi)
+	if len(attachments) == 0 {
+		fmt.Println("no attachments")
+		return nil
+	}
+
+	if attachmentsWorkItemCmdFlagDownloadDir == "" {
+		tableData := [][]string{{"Name", "Size, KB", "Date", "Comment"}}
+		for _, attachment := range attachments {
+			tableData = append(tableData, []string{
+				attachment.Name,
+				fmt.Sprintf("%.1f", float64(attachment.Size)/1024),
+				attachment.Date.Local().Format(time.DateTime),
+				cutString(attachment.Comment, 40, false),
+			})
+		}
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	}
+
+	err = os.MkdirAll(attachmentsWorkItemCmdFlagDownloadDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	fileNames := getAttachmentFileNames(attachments)
+	for i, attachment := range attachments {
+		file := filepath.Join(attachmentsWorkItemCmdFlagDownloadDir, fileNames[i])
+		err := downloadAttachment(ctx, a, attachment, file)
+		switch {
+		case err != nil:
+			failed++
+			pterm.Error.Println(fmt.Sprintf("NOT DOWNLOADED %s: %s", attachment.Name, err.Error()))
+		case fileNames[i] != filepath.Base(attachment.Name):
+			pterm.Success.Println(fmt.Sprintf("DOWNLOADED %s as %s (duplicate name)", attachment.Name, file))
+		default:
+			pterm.Success.Println(fmt.Sprintf("DOWNLOADED %s", file))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d attachments not downloaded", failed)
+	}
+	return nil
+}
+
+// getAttachmentFileNames returns unique file names of the attachments, repeated names get index suffixes
+// like "log_2.txt".
+func getAttachmentFileNames(attachments []workitem.Attachment) []string {
+	names := make([]string, 0, len(attachments))
+	used := make(map[string]bool)
+	for _, attachment := range attachments {
+		name := filepath.Base(attachment.Name)
+		ext := filepath.Ext(name)
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			name = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(filepath.Base(attachment.Name), ext), i, ext)
+		}
+
+		used[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func downloadAttachment(ctx context.Context, api *tfs.API, attachment workitem.Attachment, file string) error {
+	content, err := api.WiClient.DownloadAttachment(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	reader := newProgressReader(content, attachment.Name, attachment.Size)
+	_, err = io.Copy(f, reader)
+	reader.stop()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"tasker/editor"
+	"tasker/tfs"
+	"tasker/tfs/identity"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	goldmarktext "github.com/yuin/goldmark/text"
+)
+
+var (
+	commentCmd = &cobra.Command{
+		Use:   "comment",
+		Short: "Work item discussion",
+		Long:  `Read and write comments of work item discussion.`,
+	}
+
+	listCommentCmd = &cobra.Command{
+		Use:   "list <Work Item ID>",
+		Short: "List comments",
+		Long:  `List comments of work item discussion from the oldest one.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			err = listCommentCommand(cmd.Context(), workItemID)
+			cobra.CheckErr(err)
+		},
+	}
+
+	addCommentCmd = &cobra.Command{
+		Use:   "add <Work Item ID> [Text]",
+		Short: "Add comment",
+		Long: `Add comment to work item discussion. The text is Markdown, users are mentioned as @account or @"Display Name".
+Without text argument the text is read from file (--file, "-" for stdin) or written in editor (--editor, VISUAL or EDITOR variables).`,
+		Example: `  tasker comment add 12345 "Fixed in **1.2.3**, @ivanov please check"
+  tasker comment add 12345 --file notes.md
+  tasker comment add 12345 --editor`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			text, err := readCommentText(args[1:])
+			cobra.CheckErr(err)
+
+			err = addCommentCommand(cmd.Context(), workItemID, text)
+			cobra.CheckErr(err)
+		},
+	}
+
+	listCommentCmdFlagOutput string
+	addCommentCmdFlagFile    string
+	addCommentCmdFlagEditor  bool
+)
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+	commentCmd.AddCommand(listCommentCmd)
+	commentCmd.AddCommand(addCommentCmd)
+
+	listCommentCmd.Flags().StringVarP(&listCommentCmdFlagOutput, "output", "o", outputFormatTable, "Output format (table, json)")
+
+	addCommentCmd.Flags().StringVarP(&addCommentCmdFlagFile, "file", "F", "", "Read comment from file, \"-\" for stdin")
+	addCommentCmd.Flags().BoolVarP(&addCommentCmdFlagEditor, "editor", "e", false, "Write comment in editor")
+	addCommentCmd.MarkFlagsMutuallyExclusive("file", "editor")
+}
+
+var commentMentionRegexp = regexp.MustCompile(`(^|[^\w@])@("[^"\n]+"|\w[\w.\-\\]*\w)`)
+
+type commentOutput struct {
+	ID          int       `json:"id"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedDate time.Time `json:"createdDate"`
+	Text        string    `json:"text"`
+}
+
+func listCommentCommand(ctx context.Context, workItemID int) error {
+	err := checkOutputFormat(listCommentCmdFlagOutput, outputFormatTable, outputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	comments, err := a.WiClient.GetComments(ctx, workItemID)
+	if err != nil {
+		return err
+	}
+
+	output := lo.FilterMap(comments, func(comment workitemtracking.Comment, _ int) (commentOutput, bool) {
+		if comment.IsDeleted != nil && *comment.IsDeleted {
+			return commentOutput{}, false
+		}
+
+		result := commentOutput{}
+		if comment.Id != nil {
+			result.ID = *comment.Id
+		}
+		if comment.CreatedBy != nil && comment.CreatedBy.DisplayName != nil {
+			result.CreatedBy = *comment.CreatedBy.DisplayName
+		}
+		if comment.CreatedDate != nil {
+			result.CreatedDate = comment.CreatedDate.Time
+		}
+		if comment.Text != nil {
+			result.Text = htmlToText(*comment.Text)
+		}
+		return result, true
+	})
+
+	if listCommentCmdFlagOutput == outputFormatJSON {
+		return printJSON(output)
+	}
+
+	if len(output) == 0 {
+		fmt.Println("no comments")
+		return nil
+	}
+
+	for _, comment := range output {
+		pterm.DefaultSection.Println(fmt.Sprintf("%s, %s", comment.CreatedBy, comment.CreatedDate.Local().Format(time.DateTime)))
+		fmt.Println(comment.Text)
+	}
+
+	return nil
+}
+
+func readCommentText(args []string) (string, error) {
+	var text string
+	switch {
+	case len(args) > 0:
+		text = args[0]
+	case addCommentCmdFlagFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		text = string(data)
+	case addCommentCmdFlagFile != "":
+		data, err := os.ReadFile(addCommentCmdFlagFile)
+		if err != nil {
+			return "", err
+		}
+		text = string(data)
+	case addCommentCmdFlagEditor:
+		var err error
+		text, err = editor.Edit("")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return "", errors.New("empty comment, specify text, --file or --editor")
+	}
+	return text, nil
+}
+
+func addCommentCommand(ctx context.Context, workItemID int, text string) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = addWorkItemComment(ctx, a, workItemID, text)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Println(fmt.Sprintf("COMMENTED %d", workItemID))
+	return nil
+}
+
+// addWorkItemComment posts Markdown text with @mentions to the work item discussion.
+func addWorkItemComment(ctx context.Context, api *tfs.API, workItemID int, text string) error {
+	content, err := formatComment(ctx, api, text)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.WiClient.AddComment(ctx, workItemID, content)
+	return err
+}
+
+// formatComment converts Markdown text to HTML replacing @mentions by links to identities,
+// mentions inside code are kept as is, not found identities are kept as text with a warning.
+func formatComment(ctx context.Context, api *tfs.API, text string) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(goldmarkhtml.WithHardWraps(), goldmarkhtml.WithUnsafe()),
+	)
+
+	mentions := make(map[string]string)
+	text = replaceCommentMentions(md, text, func(name string) string {
+		mention, ok := mentions[name]
+		if ok {
+			return mention
+		}
+
+		userIdentity, err := identity.Find(ctx, api.Conn, name)
+		if err != nil {
+			pterm.Warning.Println(fmt.Sprintf("mention @%s is left as text: %s", name, err.Error()))
+		} else {
+			mention = fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`,
+				userIdentity.Id, html.EscapeString(userIdentity.DisplayName))
+		}
+		mentions[name] = mention
+		return mention
+	})
+
+	var content bytes.Buffer
+	err := md.Convert([]byte(text), &content)
+	if err != nil {
+		return "", err
+	}
+	return content.String(), nil
+}
+
+// replaceCommentMentions replaces @mentions outside of Markdown code by the resolved HTML,
+// mentions resolved to empty string are kept as is.
+func replaceCommentMentions(md goldmark.Markdown, text string, resolve func(name string) string) string {
+	source := []byte(text)
+	codeSegments := getMarkdownCodeSegments(md.Parser().Parse(goldmarktext.NewReader(source)))
+
+	var replaced strings.Builder
+	var last int
+	for _, match := range commentMentionRegexp.FindAllStringSubmatchIndex(text, -1) {
+		// the second group is the name after "@"
+		start, stop := match[4]-1, match[5]
+		if slices.ContainsFunc(codeSegments, func(segment goldmarktext.Segment) bool {
+			return start >= segment.Start && start < segment.Stop
+		}) {
+			continue
+		}
+
+		mention := resolve(strings.Trim(text[match[4]:stop], `"`))
+		if mention == "" {
+			continue
+		}
+
+		replaced.WriteString(text[last:start])
+		replaced.WriteString(mention)
+		last = stop
+	}
+	replaced.WriteString(text[last:])
+
+	return replaced.String()
+}
+
+// getMarkdownCodeSegments returns source segments of code spans, code blocks and HTML blocks.
+func getMarkdownCodeSegments(doc ast.Node) []goldmarktext.Segment {
+	var segments []goldmarktext.Segment
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n.Kind() {
+		case ast.KindCodeSpan:
+			for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+				if t, ok := child.(*ast.Text); ok {
+					segments = append(segments, t.Segment)
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case ast.KindCodeBlock, ast.KindFencedCodeBlock, ast.KindHTMLBlock:
+			lines := n.Lines()
+			for i := range lines.Len() {
+				segments = append(segments, lines.At(i))
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return segments
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+func Test_replaceCommentMentions(t *testing.T) {
+	resolve := func(name string) string {
+		if name == "unknown" {
+			return ""
+		}
+		return "<" + name + ">"
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "login", text: "fixed, @ivanov please check", want: "fixed, <ivanov> please check"},
+		{name: "quoted name", text: `@"Иван Иванов" done`, want: "<Иван Иванов> done"},
+		{name: "email", text: "mail user@example.com", want: "mail user@example.com"},
+		{name: "not resolved", text: "@unknown and @petrov", want: "@unknown and <petrov>"},
+		{name: "code span", text: "call `@Override` for @petrov", want: "call `@Override` for <petrov>"},
+		{name: "fenced code", text: "@petrov\n```\n@decorator\n```\n", want: "<petrov>\n```\n@decorator\n```\n"},
+		{name: "indented code", text: "see:\n\n    @annotation\n", want: "see:\n\n    @annotation\n"},
+	}
+
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replaceCommentMentions(md, tt.text, resolve))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyWorkItemCmd = &cobra.Command{
+		Use:   "history <Work Item ID>",
+		Short: "Show history of work item changes",
+		Long: `Show field changes of the work item revisions in chronological order: old → new value, who and when changed.
+Changes of HTML fields (description etc.) are shown as a text diff.`,
+		Example: `  tasker history 12345 --field State --field RemainingWork
+  tasker history 12345 --since 2024-03-01 --output json`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			err = historyWorkItemCommand(cmd.Context(), workItemID)
+			cobra.CheckErr(err)
+		},
+	}
+
+	historyWorkItemCmdFlagFields []string
+	historyWorkItemCmdFlagSince  string
+	historyWorkItemCmdFlagUntil  string
+	historyWorkItemCmdFlagOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(historyWorkItemCmd)
+
+	historyWorkItemCmd.Flags().StringSliceVarP(&historyWorkItemCmdFlagFields, "field", "f", nil, "Only changes of the fields, friendly or reference names. Can be separated by comma or specified multiple times.")
+	historyWorkItemCmd.Flags().StringVarP(&historyWorkItemCmdFlagSince, "since", "", "", "Only changes made on or after the date (YYYY-MM-DD)")
+	historyWorkItemCmd.Flags().StringVarP(&historyWorkItemCmdFlagUntil, "until", "", "", "Only changes made on or before the date (YYYY-MM-DD)")
+	historyWorkItemCmd.Flags().StringVarP(&historyWorkItemCmdFlagOutput, "output", "o", outputFormatTable, "Output format (table, json)")
+}
+
+type workItemHistoryChange struct {
+	Field    string `json:"field"`
+	OldValue any    `json:"oldValue"`
+	NewValue any    `json:"newValue"`
+	// Diff is the text diff of HTML values
+	Diff string `json:"diff,omitempty"`
+}
+
+type workItemHistoryRevision struct {
+	Rev         int                     `json:"rev"`
+	ChangedBy   string                  `json:"changedBy"`
+	ChangedDate time.Time               `json:"changedDate"`
+	Changes     []workItemHistoryChange `json:"changes"`
+}
+
+func historyWorkItemCommand(ctx context.Context, workItemID int) error {
+	err := checkOutputFormat(historyWorkItemCmdFlagOutput, outputFormatTable, outputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	since, until, err := parseDateRange(historyWorkItemCmdFlagSince, historyWorkItemCmdFlagUntil)
+	if err != nil {
+		return err
+	}
+
+	fields := lo.Map(historyWorkItemCmdFlagFields, func(name string, _ int) string {
+		return getWorkItemFieldReferenceName(strings.TrimSpace(name))
+	})
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading revisions...")
+	revisions, err := a.WiClient.GetRevisions(ctx, workItemID)
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	history := make([]workItemHistoryRevision, 0)
+	for _, revision := range workitem.GetRevisionsChanges(revisions) {
+		if (!since.IsZero() && revision.ChangedDate.Before(since)) || (!until.IsZero() && !revision.ChangedDate.Before(until)) {
+			continue
+		}
+
+		item := workItemHistoryRevision{
+			Rev:         revision.Rev,
+			ChangedBy:   formatWorkItemFieldValue(revision.ChangedBy),
+			ChangedDate: revision.ChangedDate,
+		}
+		for _, change := range revision.Changes {
+			if len(fields) > 0 && !lo.Contains(fields, change.Field) {
+				continue
+			}
+			item.Changes = append(item.Changes, getWorkItemHistoryChange(change))
+		}
+
+		if len(item.Changes) > 0 {
+			history = append(history, item)
+		}
+	}
+
+	if historyWorkItemCmdFlagOutput == outputFormatJSON {
+		return printJSON(history)
+	}
+
+	if len(history) == 0 {
+		fmt.Println("no changes")
+		return nil
+	}
+
+	printWorkItemHistory(history)
+	return nil
+}
+
+// parseDateRange parses dates of the range, the end of the range is moved to the next day to include the whole day.
+func parseDateRange(since, until string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		from, err = time.ParseInLocation(time.DateOnly, since, time.Local)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD", since)
+		}
+	}
+	if until != "" {
+		to, err = time.ParseInLocation(time.DateOnly, until, time.Local)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD", until)
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+func getWorkItemHistoryChange(change workitem.FieldChange) workItemHistoryChange {
+	result := workItemHistoryChange{
+		Field:    change.Field,
+		OldValue: change.OldValue,
+		NewValue: change.NewValue,
+	}
+
+	oldValue, newValue := formatWorkItemFieldValue(change.OldValue), formatWorkItemFieldValue(change.NewValue)
+	if change.Field != "System.History" && (isHTML(oldValue) || isHTML(newValue)) {
+		result.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(htmlToText(oldValue)),
+			B:        difflib.SplitLines(htmlToText(newValue)),
+			FromFile: "old",
+			ToFile:   "new",
+			Context:  1,
+		})
+	}
+
+	return result
+}
+
+func printWorkItemHistory(history []workItemHistoryRevision) {
+	for _, revision := range history {
+		pterm.DefaultSection.Println(fmt.Sprintf("Rev %d, %s, %s", revision.Rev,
+			revision.ChangedDate.Local().Format(time.DateTime), revision.ChangedBy))
+
+		tableData := [][]string{{"Field", "Change"}}
+		var diffs []workItemHistoryChange
+		for _, change := range revision.Changes {
+			switch {
+			case change.Diff != "":
+				diffs = append(diffs, change)
+			case change.Field == "System.History":
+				tableData = append(tableData, []string{"Comment", cutString(htmlToText(formatWorkItemFieldValue(change.NewValue)), 80, false)})
+			default:
+				tableData = append(tableData, []string{change.Field, fmt.Sprintf("%s → %s",
+					cutString(formatWorkItemFieldValue(change.OldValue), 40, false),
+					cutString(formatWorkItemFieldValue(change.NewValue), 40, false))})
+			}
+		}
+
+		if len(tableData) > 1 {
+			_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+		}
+
+		for _, change := range diffs {
+			fmt.Println(change.Field + ":")
+			printTextDiff(change.Diff)
+		}
+	}
+}
+
+func printTextDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(pterm.Green(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(pterm.Red(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(pterm.Cyan(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	htmlTagRegexp   = regexp.MustCompile(`<[a-zA-Z/][^>]*>`)
+	emptyLineRegexp = regexp.MustCompile(`\n\s*\n\s*\n+`)
+)
+
+func isHTML(value string) bool {
+	return htmlTagRegexp.MatchString(value)
+}
+
+// htmlToText returns text of HTML with line breaks in place of block elements.
+func htmlToText(value string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(value))
+	if err != nil {
+		return value
+	}
+
+	doc.Find("br").ReplaceWithHtml("\n")
+	doc.Find("li").PrependHtml("- ")
+	doc.Find("p, div, li, tr, h1, h2, h3, h4, h5, h6, pre, blockquote").AppendHtml("\n")
+	doc.Find("td, th").AppendHtml("\t")
+
+	text := strings.ReplaceAll(doc.Text(), "\u00a0", " ")
+	return strings.TrimSpace(emptyLineRegexp.ReplaceAllString(text, "\n\n"))
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkWorkItemsCmd = &cobra.Command{
+		Use:   "link <Source Work Item ID> <Target Work Item ID, ...>",
+		Short: "Link work items",
+		Long: `Link the source work item with the target work items, existing links are kept as is.
+Type tells what the target is for the source: related, parent, child, predecessor, successor, duplicate, duplicate-of, affects, affected-by or reference name of link type.`,
+		Example: `  tasker link 123 456 --type predecessor
+  tasker link 123 456 789 --type child`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemIDs, err := parseWorkItemIDs(args)
+			cobra.CheckErr(err)
+
+			err = linkWorkItemsCommand(cmd.Context(), workItemIDs[0], workItemIDs[1:])
+			cobra.CheckErr(err)
+		},
+	}
+
+	unlinkWorkItemsCmd = &cobra.Command{
+		Use:   "unlink <Source Work Item ID> <Target Work Item ID, ...>",
+		Short: "Remove links between work items",
+		Long:  "Remove links of the type (all links if no type specified) between the source work item and the target work items.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemIDs, err := parseWorkItemIDs(args)
+			cobra.CheckErr(err)
+
+			err = unlinkWorkItemsCommand(cmd.Context(), workItemIDs[0], workItemIDs[1:])
+			cobra.CheckErr(err)
+		},
+	}
+
+	linksWorkItemCmd = &cobra.Command{
+		Use:   "links <Work Item ID>",
+		Short: "List links of work item",
+		Long:  "List work items linked with the work item.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID, err := strconv.Atoi(args[0])
+			cobra.CheckErr(err)
+
+			err = linksWorkItemCommand(cmd.Context(), workItemID)
+			cobra.CheckErr(err)
+		},
+	}
+
+	linkWorkItemsCmdFlagType    string
+	linkWorkItemsCmdFlagComment string
+	unlinkWorkItemsCmdFlagType  string
+	linksWorkItemCmdFlagOutput  string
+)
+
+func init() {
+	rootCmd.AddCommand(linkWorkItemsCmd)
+	rootCmd.AddCommand(unlinkWorkItemsCmd)
+	rootCmd.AddCommand(linksWorkItemCmd)
+
+	linkWorkItemsCmd.Flags().StringVarP(&linkWorkItemsCmdFlagType, "type", "t", "related", "Link type")
+	linkWorkItemsCmd.Flags().StringVarP(&linkWorkItemsCmdFlagComment, "comment", "c", "", "Comment of the links")
+	unlinkWorkItemsCmd.Flags().StringVarP(&unlinkWorkItemsCmdFlagType, "type", "t", "", "Link type, all links if empty")
+	linksWorkItemCmd.Flags().StringVarP(&linksWorkItemCmdFlagOutput, "output", "o", outputFormatTable, "Output format (table, json)")
+}
+
+type workItemLinkOutput struct {
+	Type     string `json:"type"`
+	ID       int    `json:"id"`
+	ItemType string `json:"itemType"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+func linkWorkItemsCommand(ctx context.Context, sourceID int, targetIDs []int) error {
+	linkType, err := workitem.GetLinkType(linkWorkItemsCmdFlagType)
+	if err != nil {
+		return err
+	}
+
+	if lo.Contains(targetIDs, sourceID) {
+		return errors.New("work item can't be linked with itself")
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	source, err := a.WiClient.GetExpanded(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	targets, err := a.WiClient.GetList(ctx, lo.Uniq(targetIDs), []string{"System.Id", "System.Title"})
+	if err != nil {
+		return err
+	}
+
+	var relations []*workitem.Relation
+	var linked []workitemtracking.WorkItem
+	for _, target := range targets {
+		if len(workitem.FindLinks(source, linkType, *target.Id)) > 0 {
+			pterm.Info.Println(fmt.Sprintf("ALREADY LINKED %d %s", *target.Id, workitem.GetTitle(&target)))
+			continue
+		}
+
+		relations = append(relations, &workitem.Relation{
+			URL:     *target.Url,
+			Type:    linkType,
+			Comment: linkWorkItemsCmdFlagComment,
+		})
+		linked = append(linked, target)
+	}
+
+	if len(relations) == 0 {
+		return nil
+	}
+
+	_, err = a.WiClient.AddRelations(ctx, sourceID, relations...)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range linked {
+		pterm.Success.Println(fmt.Sprintf("LINKED %d %s as %s of %d", *target.Id, workitem.GetTitle(&target),
+			workitem.GetLinkTypeName(linkType), sourceID))
+	}
+	return nil
+}
+
+func unlinkWorkItemsCommand(ctx context.Context, sourceID int, targetIDs []int) error {
+	var linkType string
+	if unlinkWorkItemsCmdFlagType != "" {
+		var err error
+		linkType, err = workitem.GetLinkType(unlinkWorkItemsCmdFlagType)
+		if err != nil {
+			return err
+		}
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	source, err := a.WiClient.GetExpanded(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	var indexes, unlinked []int
+	for _, targetID := range lo.Uniq(targetIDs) {
+		found := workitem.FindLinks(source, linkType, targetID)
+		if len(found) == 0 {
+			pterm.Info.Println(fmt.Sprintf("NOT LINKED %d", targetID))
+			continue
+		}
+
+		indexes = append(indexes, found...)
+		unlinked = append(unlinked, targetID)
+	}
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	_, err = a.WiClient.RemoveRelations(workitem.WithPrevious(ctx, source), sourceID, *source.Rev, indexes...)
+	if err != nil {
+		return err
+	}
+
+	for _, targetID := range unlinked {
+		pterm.Success.Println(fmt.Sprintf("UNLINKED %d from %d", targetID, sourceID))
+	}
+	return nil
+}
+
+func linksWorkItemCommand(ctx context.Context, workItemID int) error {
+	err := checkOutputFormat(linksWorkItemCmdFlagOutput, outputFormatTable, outputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	wi, err := a.WiClient.GetExpanded(ctx, workItemID)
+	if err != nil {
+		return err
+	}
+
+	links := getWorkItemLinks(wi)
+	if len(links) > 0 {
+		linkedWorkItems, err := a.WiClient.GetList(ctx, lo.Uniq(lo.Map(links, func(link workItemLinkOutput, _ int) int {
+			return link.ID
+		})), []string{"System.Id", "System.WorkItemType", "System.Title", "System.State"})
+		if err != nil {
+			return err
+		}
+
+		linkedByID := lo.KeyBy(linkedWorkItems, func(linked workitemtracking.WorkItem) int {
+			return *linked.Id
+		})
+		for i := range links {
+			linked := linkedByID[links[i].ID]
+			links[i].ItemType = workitem.GetType(&linked)
+			links[i].Title = workitem.GetTitle(&linked)
+			links[i].State = workitem.GetState(&linked)
+		}
+	}
+
+	if linksWorkItemCmdFlagOutput == outputFormatJSON {
+		return printJSON(links)
+	}
+
+	if len(links) == 0 {
+		fmt.Println("no links")
+		return nil
+	}
+
+	tableData := [][]string{{"Link", "ID", "Type", "Title", "State", "Comment"}}
+	for _, link := range links {
+		tableData = append(tableData, []string{
+			link.Type,
+			strconv.Itoa(link.ID),
+			link.ItemType,
+			cutString(link.Title, 60, false),
+			link.State,
+			cutString(link.Comment, 30, false),
+		})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+}
+
+// getWorkItemLinks returns links to other work items grouped by link type.
+func getWorkItemLinks(wi *workitemtracking.WorkItem) []workItemLinkOutput {
+	links := make([]workItemLinkOutput, 0)
+	if wi.Relations == nil {
+		return links
+	}
+
+	for _, relation := range *wi.Relations {
+		id, ok := workitem.GetRelationWorkItemID(relation)
+		if !ok {
+			continue
+		}
+
+		link := workItemLinkOutput{Type: workitem.GetLinkTypeName(*relation.Rel), ID: id}
+		if relation.Attributes != nil {
+			link.Comment, _ = (*relation.Attributes)["comment"].(string)
+		}
+		links = append(links, link)
+	}
+
+	slices.SortStableFunc(links, func(a, b workItemLinkOutput) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	return links
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
+
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// maxBareHours limits time without unit logged to the active task,
+// a bigger number is likely a work item ID specified without time.
+const maxBareHours = 24
+
+var (
+	logWorkItemCmd = &cobra.Command{
+		Use:   "log [Work Item ID] <Time>",
+		Short: "Log time spent on work item",
+		Long: `Add spent time to Completed Work of the work item and decrease its Remaining Work.
+Time is specified in hours (1.5) or as duration (1h30m). Without ID the time is logged to your Active task in the current iteration,
+then time without unit is limited to 24 hours to not confuse it with ID.`,
+		Example: `  tasker log 3h
+  tasker log 12345 2h --remaining 4h --comment "review fixes"`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemID := 0
+			if len(args) == 2 {
+				var err error
+				workItemID, err = strconv.Atoi(args[0])
+				cobra.CheckErr(err)
+			}
+
+			hours, err := parseHours(args[len(args)-1])
+			cobra.CheckErr(err)
+
+			if len(args) == 1 {
+				err = checkBareHours(args[0], hours)
+				cobra.CheckErr(err)
+			}
+
+			err = logWorkItemCommand(cmd.Context(), workItemID, hours)
+			cobra.CheckErr(err)
+		},
+	}
+
+	logWorkItemCmdFlagRemaining string
+	logWorkItemCmdFlagComment   string
+)
+
+func init() {
+	rootCmd.AddCommand(logWorkItemCmd)
+
+	logWorkItemCmd.Flags().StringVarP(&logWorkItemCmdFlagRemaining, "remaining", "r", "", "Set Remaining Work instead of decreasing it by logged time")
+	logWorkItemCmd.Flags().StringVarP(&logWorkItemCmdFlagComment, "comment", "c", "", "Comment (Markdown, @mentions) posted to the work item discussion")
+}
+
+// parseHours parses hours as a number or as a duration like 1h30m.
+func parseHours(value string) (float64, error) {
+	var hours float64
+	if duration, err := time.ParseDuration(value); err == nil {
+		hours = duration.Hours()
+	} else {
+		hours, err = strconv.ParseFloat(strings.TrimSuffix(value, "h"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time '%s', expected hours (1.5) or duration (1h30m)", value)
+		}
+	}
+
+	if hours < 0 {
+		return 0, fmt.Errorf("invalid time '%s', must not be negative", value)
+	}
+	return hours, nil
+}
+
+// checkBareHours rejects a lone number without unit which looks like a work item ID.
+func checkBareHours(value string, hours float64) error {
+	if _, err := strconv.ParseFloat(value, 64); err != nil || hours <= maxBareHours {
+		return nil
+	}
+	return fmt.Errorf("time '%s' looks like a work item ID, specify time with unit (%sh) or both ID and time", value, value)
+}
+
+func logWorkItemCommand(ctx context.Context, workItemID int, hours float64) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	if workItemID == 0 {
+		workItemID, err = findMyActiveTaskID(ctx, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	return logWorkItemTime(ctx, a, workItemID, hours, logWorkItemCmdFlagRemaining, logWorkItemCmdFlagComment)
+}
+
+func findMyActiveTaskID(ctx context.Context, api *tfs.API) (int, error) {
+	ids, err := api.GetMyCurrentWorkItemIDs(ctx,
+		wiql.Eq("System.WorkItemType", "Task"),
+		wiql.Eq("System.State", "Active"),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(ids) {
+	case 0:
+		return 0, errors.New("no Active task assigned to you in the current iteration, specify work item ID")
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, fmt.Errorf("several Active tasks assigned to you in the current iteration (%s), specify work item ID",
+			strings.Join(lo.Map(ids, func(id int, _ int) string { return strconv.Itoa(id) }), ", "))
+	}
+}
+
+// logWorkItemTime adds hours to Completed Work and decreases Remaining Work by them or sets it to remaining if specified.
+func logWorkItemTime(ctx context.Context, api *tfs.API, workItemID int, hours float64, remaining, comment string) error {
+	wi, err := api.WiClient.Get(ctx, workItemID)
+	if err != nil {
+		return err
+	}
+
+	completedWork := workitem.GetCompletedWork64(wi) + hours
+	remainingWork := workitem.GetRemainingWork64(wi) - hours
+	if remaining != "" {
+		remainingWork, err = parseHours(remaining)
+		if err != nil {
+			return err
+		}
+	}
+
+	if remainingWork < 0 {
+		return fmt.Errorf("remaining work of %d would become negative (%g), specify --remaining", workItemID, remainingWork)
+	}
+
+	_, err = api.WiClient.UpdateFieldsAtRevision(workitem.WithPrevious(ctx, wi), workItemID, *wi.Rev, map[string]any{
+		"Microsoft.VSTS.Scheduling.CompletedWork": completedWork,
+		"Microsoft.VSTS.Scheduling.RemainingWork": remainingWork,
+	})
+	if err != nil {
+		return err
+	}
+
+	if comment != "" {
+		err = addWorkItemComment(ctx, api, workItemID, comment)
+		if err != nil {
+			return fmt.Errorf("time logged, but comment not added: %w", err)
+		}
+	}
+
+	pterm.Success.Println(fmt.Sprintf("LOGGED %gh to %d %s: completed %gh, remaining %gh",
+		hours, workItemID, workitem.GetTitle(wi), completedWork, remainingWork))
+
+	return nil
+}
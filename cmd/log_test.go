@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkBareHours(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "3"},
+		{value: "1.5"},
+		{value: "24"},
+		{value: "30h"},
+		{value: "100h30m"},
+		{value: "25", wantErr: true},
+		{value: "12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			hours, err := parseHours(tt.value)
+			assert.NoError(t, err)
+
+			err = checkBareHours(tt.value, hours)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/browser"
+	"tasker/tasksui"
+	"tasker/tfs"
+	"tasker/tfs/identity"
+	"tasker/tfs/pr"
+	"tasker/tfs/workitem"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	myCmd = &cobra.Command{
+		Use:   "my",
+		Short: "Show my work items and pull requests",
+		Long: `Show work items assigned to you in the current iteration grouped by state with remaining work
+and your active pull requests across repositories of the project.
+Quick actions are available from the interactive list: activate, log time, close and open in browser.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := myCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	myCmdFlagNoActions bool
+)
+
+func init() {
+	rootCmd.AddCommand(myCmd)
+
+	myCmd.Flags().BoolVarP(&myCmdFlagNoActions, "no-actions", "n", false, "Only print work items and pull requests without the interactive list")
+}
+
+const (
+	myActionActivate = 'a'
+	myActionLog      = 'l'
+	myActionClose    = 'c'
+	myActionOpen     = 'o'
+)
+
+var myActions = []tasksui.Action{
+	{Key: myActionActivate, Title: "activate"},
+	{Key: myActionLog, Title: "log time"},
+	{Key: myActionClose, Title: "close"},
+	{Key: myActionOpen, Title: "open in browser"},
+}
+
+type myWorkState struct {
+	state     string
+	workItems []workitemtracking.WorkItem
+}
+
+type myWork struct {
+	states       []myWorkState
+	pullRequests []git.GitPullRequest
+}
+
+// myListEntry links an item of the interactive list with the work item or pull request it shows.
+type myListEntry struct {
+	workItem    *workitemtracking.WorkItem
+	pullRequest *git.GitPullRequest
+}
+
+func myCommand(ctx context.Context) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		work, err := loadMyWork(ctx, a)
+		if err != nil {
+			return err
+		}
+
+		if myCmdFlagNoActions {
+			printMyWork(work)
+			return nil
+		}
+
+		entries, items := getMyListItems(work)
+		if len(entries) == 0 {
+			fmt.Println("nothing assigned to you in the current iteration")
+			return nil
+		}
+
+		index, action, err := tasksui.SelectAction("My work", items, myActions)
+		if err != nil {
+			return err
+		}
+		if index < 0 {
+			printMyWork(work)
+			return nil
+		}
+
+		err = runMyAction(ctx, a, entries[index], action)
+		if err != nil {
+			pterm.Error.Println(err.Error())
+		}
+	}
+}
+
+func loadMyWork(ctx context.Context, api *tfs.API) (*myWork, error) {
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading...")
+	defer func() {
+		_ = spinner.Stop()
+	}()
+
+	ids, err := api.GetMyCurrentWorkItemIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var workItems []workitemtracking.WorkItem
+	if len(ids) > 0 {
+		workItems, err = api.WiClient.GetList(ctx, ids, []string{
+			"System.Id",
+			"System.WorkItemType",
+			"System.Title",
+			"System.State",
+			"Microsoft.VSTS.Scheduling.RemainingWork",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	work := &myWork{}
+	for _, wi := range workItems {
+		state := workitem.GetState(&wi)
+		i := slices.IndexFunc(work.states, func(s myWorkState) bool { return s.state == state })
+		if i < 0 {
+			work.states = append(work.states, myWorkState{state: state})
+			i = len(work.states) - 1
+		}
+		work.states[i].workItems = append(work.states[i].workItems, wi)
+	}
+
+	work.pullRequests, err = getMyPullRequests(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	return work, nil
+}
+
+func getMyPullRequests(ctx context.Context, api *tfs.API) ([]git.GitPullRequest, error) {
+	userIdentity, err := identity.Get(ctx, api.Conn)
+	if err != nil {
+		return nil, err
+	}
+
+	creatorID, err := uuid.Parse(userIdentity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := pr.NewClient(ctx, api.Conn, api.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetActivePullRequestsByCreator(ctx, creatorID)
+}
+
+func getRemainingWork(workItems []workitemtracking.WorkItem) float32 {
+	return lo.SumBy(workItems, func(wi workitemtracking.WorkItem) float32 {
+		return workitem.GetRemainingWork(&wi)
+	})
+}
+
+func getMyStateTitle(state myWorkState) string {
+	return fmt.Sprintf("%s (%d, remaining %gh)", state.state, len(state.workItems), getRemainingWork(state.workItems))
+}
+
+func getMyPullRequestDescription(pullRequest *git.GitPullRequest) string {
+	return fmt.Sprintf("%s: %s → %s", *pullRequest.Repository.Name,
+		shortBranchName(*pullRequest.SourceRefName), shortBranchName(*pullRequest.TargetRefName))
+}
+
+func shortBranchName(name string) string {
+	return strings.TrimPrefix(name, "refs/heads/")
+}
+
+func printMyWork(work *myWork) {
+	var total float32
+	for _, state := range work.states {
+		total += getRemainingWork(state.workItems)
+
+		pterm.DefaultSection.Println(getMyStateTitle(state))
+		tableData := [][]string{{"ID", "Type", "Title", "Remaining"}}
+		for _, wi := range state.workItems {
+			tableData = append(tableData, []string{
+				strconv.Itoa(*wi.Id),
+				workitem.GetType(&wi),
+				cutString(workitem.GetTitle(&wi), 60, false),
+				fmt.Sprintf("%g", workitem.GetRemainingWork(&wi)),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	}
+	pterm.Info.Println(fmt.Sprintf("total remaining: %gh", total))
+
+	pterm.DefaultSection.Println(fmt.Sprintf("Pull requests (%d)", len(work.pullRequests)))
+	for _, pullRequest := range work.pullRequests {
+		fmt.Printf("%d %s\n  %s\n  %s\n", *pullRequest.PullRequestId, *pullRequest.Title,
+			getMyPullRequestDescription(&pullRequest), pr.GetPullRequestURL(&pullRequest))
+	}
+}
+
+func getMyListItems(work *myWork) ([]myListEntry, []tasksui.ActionItem) {
+	var entries []myListEntry
+	var items []tasksui.ActionItem
+	workItemActions := []rune{myActionOpen, myActionActivate, myActionLog, myActionClose}
+
+	var total float32
+	for _, state := range work.states {
+		total += getRemainingWork(state.workItems)
+
+		entries = append(entries, myListEntry{})
+		items = append(items, tasksui.ActionItem{Text: getMyStateTitle(state)})
+		for _, wi := range state.workItems {
+			entries = append(entries, myListEntry{workItem: &wi})
+			items = append(items, tasksui.ActionItem{
+				Text:        fmt.Sprintf("%d %s", *wi.Id, workitem.GetTitle(&wi)),
+				Description: fmt.Sprintf("%s, remaining %gh", workitem.GetType(&wi), workitem.GetRemainingWork(&wi)),
+				Actions:     workItemActions,
+			})
+		}
+	}
+
+	if len(work.states) > 0 {
+		entries = append(entries, myListEntry{})
+		items = append(items, tasksui.ActionItem{Text: fmt.Sprintf("Total remaining %gh", total)})
+	}
+
+	entries = append(entries, myListEntry{})
+	items = append(items, tasksui.ActionItem{Text: fmt.Sprintf("Pull requests (%d)", len(work.pullRequests))})
+	for _, pullRequest := range work.pullRequests {
+		entries = append(entries, myListEntry{pullRequest: &pullRequest})
+		items = append(items, tasksui.ActionItem{
+			Text:        fmt.Sprintf("%d %s", *pullRequest.PullRequestId, *pullRequest.Title),
+			Description: getMyPullRequestDescription(&pullRequest),
+			Actions:     []rune{myActionOpen},
+		})
+	}
+
+	if len(work.states) == 0 && len(work.pullRequests) == 0 {
+		return nil, nil
+	}
+	return entries, items
+}
+
+func runMyAction(ctx context.Context, api *tfs.API, entry myListEntry, action rune) error {
+	if entry.pullRequest != nil {
+		return browser.OpenURL(pr.GetPullRequestURL(entry.pullRequest))
+	}
+
+	workItemID := *entry.workItem.Id
+	switch action {
+	case myActionActivate:
+		return transitionWorkItemsCommand(ctx, []int{workItemID}, "Active", workItemTransitionOptions{})
+	case myActionLog:
+		value, err := pterm.DefaultInteractiveTextInput.
+			WithMultiLine(false).
+			Show(fmt.Sprintf("Time spent on %d (1.5 or 1h30m)", workItemID))
+		if err != nil {
+			return err
+		}
+
+		hours, err := parseHours(value)
+		if err != nil {
+			return err
+		}
+		return logWorkItemTime(ctx, api, workItemID, hours, "", "")
+	case myActionClose:
+		return closeWorkItemsCommand(ctx, []int{workItemID}, "")
+	case myActionOpen:
+		return browser.OpenURL(workitem.GetURL(entry.workItem))
+	}
+
+	return nil
+}
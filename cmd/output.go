@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+)
+
+const (
+	outputFormatTable    = "table"
+	outputFormatJSON     = "json"
+	outputFormatCSV      = "csv"
+	outputFormatMarkdown = "markdown"
+)
+
+var outputFormats = []string{outputFormatTable, outputFormatJSON, outputFormatCSV, outputFormatMarkdown}
+
+func checkOutputFormat(format string, formats ...string) error {
+	if len(formats) == 0 {
+		formats = outputFormats
+	}
+	if !lo.Contains(formats, format) {
+		return fmt.Errorf("unsupported output format '%s', expected one of: %s", format, strings.Join(formats, ", "))
+	}
+	return nil
+}
+
+// printTable prints data with the header in the first row in the table, csv or markdown format.
+func printTable(format string, data [][]string) error {
+	switch format {
+	case outputFormatTable:
+		return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	case outputFormatCSV:
+		w := csv.NewWriter(os.Stdout)
+		return w.WriteAll(data)
+	case outputFormatMarkdown:
+		fmt.Print(getMarkdownTable(data))
+		return nil
+	default:
+		return fmt.Errorf("unsupported table output format '%s'", format)
+	}
+}
+
+func printJSON(obj any) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// getMarkdownTable returns data with the header in the first row as markdown table.
+func getMarkdownTable(data [][]string) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		cells = lo.Map(cells, func(cell string, _ int) string {
+			return strings.ReplaceAll(strings.ReplaceAll(cell, "|", "\\|"), "\n", " ")
+		})
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	writeRow(data[0])
+	writeRow(lo.Map(data[0], func(string, int) string { return "---" }))
+	for _, row := range data[1:] {
+		writeRow(row)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"tasker/tfs"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreWorkItemsCmd = &cobra.Command{
+		Use:   "restore <Work Item ID, ...>",
+		Short: "Restore work items",
+		Long:  "Restore deleted work items from the recycle bin by ID.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemIDs, err := parseWorkItemIDs(args)
+			cobra.CheckErr(err)
+
+			err = restoreWorkItemsCommand(cmd.Context(), workItemIDs)
+			cobra.CheckErr(err)
+		},
+	}
+
+	recycleBinCmd = &cobra.Command{
+		Use:   "recycle-bin",
+		Short: "Recycle bin of work items",
+		Long:  "Browse work items deleted into the recycle bin of the project.",
+	}
+
+	listRecycleBinCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List deleted work items",
+		Long:  "List work items in the recycle bin of the project from the latest deleted one.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := listRecycleBinCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	listRecycleBinCmdFlagOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(restoreWorkItemsCmd)
+	rootCmd.AddCommand(recycleBinCmd)
+	recycleBinCmd.AddCommand(listRecycleBinCmd)
+
+	listRecycleBinCmd.Flags().StringVarP(&listRecycleBinCmdFlagOutput, "output", "o", outputFormatTable, "Output format (table, json)")
+}
+
+type deletedWorkItemOutput struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	DeletedBy   string `json:"deletedBy"`
+	DeletedDate string `json:"deletedDate"`
+}
+
+func restoreWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, workItemID := range workItemIDs {
+		err := a.WiClient.Restore(ctx, workItemID)
+		if err != nil {
+			failed++
+			pterm.Error.Println(fmt.Sprintf("NOT RESTORED %d: %s", workItemID, err.Error()))
+			continue
+		}
+		pterm.Success.Println(fmt.Sprintf("RESTORED %d", workItemID))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d work items not restored", failed)
+	}
+	return nil
+}
+
+func listRecycleBinCommand(ctx context.Context) error {
+	err := checkOutputFormat(listRecycleBinCmdFlagOutput, outputFormatTable, outputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := a.WiClient.GetRecycleBin(ctx)
+	if err != nil {
+		return err
+	}
+
+	items := lo.Map(deleted, func(reference workitemtracking.WorkItemDeleteReference, _ int) deletedWorkItemOutput {
+		return deletedWorkItemOutput{
+			ID:          lo.FromPtr(reference.Id),
+			Type:        lo.FromPtr(reference.Type),
+			Title:       lo.FromPtr(reference.Name),
+			DeletedBy:   lo.FromPtr(reference.DeletedBy),
+			DeletedDate: lo.FromPtr(reference.DeletedDate),
+		}
+	})
+	// dates are in ISO 8601 format and are compared as strings
+	slices.SortFunc(items, func(a, b deletedWorkItemOutput) int {
+		return -cmp.Compare(a.DeletedDate, b.DeletedDate)
+	})
+
+	if listRecycleBinCmdFlagOutput == outputFormatJSON {
+		return printJSON(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("recycle bin is empty")
+		return nil
+	}
+
+	tableData := [][]string{{"ID", "Type", "Title", "Deleted By", "Deleted"}}
+	for _, item := range items {
+		deletedDate := item.DeletedDate
+		if t, err := time.Parse(time.RFC3339, item.DeletedDate); err == nil {
+			deletedDate = t.Local().Format(time.DateTime)
+		}
+
+		tableData = append(tableData, []string{
+			strconv.Itoa(item.ID),
+			item.Type,
+			cutString(item.Title, 60, false),
+			item.DeletedBy,
+			deletedDate,
+		})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+}
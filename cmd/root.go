@@ -43,6 +43,10 @@ func initConfig() {
 	viper.SetDefault("tfsBugTitleTemplate", defaultBugTitleTemplate)
 	viper.SetDefault("wikiAccessToken", "")
 	viper.SetDefault("wikiBaseAddress", "https://wiki.infotecs.int")
+	viper.SetDefault("techDebtClosedStates", []string{"Closed", "Resolved"})
+	viper.SetDefault("workItemTransitionRules", defaultWorkItemTransitionRules)
+	viper.SetDefault("techDebtAgeBuckets", []int{90, 180, 365})
+	viper.SetDefault("techDebtInactivityBuckets", []int{30, 90, 180, 365})
 
 	if cfgFile != "" {
 		// Use config file from the flag.
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	setWorkItemsCmd = &cobra.Command{
+		Use:   "set [Work Item ID, ...]",
+		Short: "Set fields of work items",
+		Long: `Set fields of work items by ID, by WIQL query or by IDs read from stdin ("-" argument).
+Changes are previewed before applying, work items changed by someone else in the meantime are not updated.`,
+		Example: `  tasker set 123 456 --field AreaPath='NSMS\SMP' --field Priority=2
+  tasker query --parent 123 | tasker set - --field State=Closed --yes
+  tasker set --wiql "SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'old'" --field Tags=`,
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemIDs, err := parseWorkItemIDs(args)
+			cobra.CheckErr(err)
+
+			err = setWorkItemsCommand(cmd.Context(), workItemIDs, lo.Contains(args, "-"))
+			cobra.CheckErr(err)
+		},
+	}
+
+	setWorkItemsCmdFlagFields []string
+	setWorkItemsCmdFlagWiql   string
+	setWorkItemsCmdFlagYes    bool
+)
+
+func init() {
+	rootCmd.AddCommand(setWorkItemsCmd)
+
+	setWorkItemsCmd.Flags().StringArrayVarP(&setWorkItemsCmdFlagFields, "field", "f", nil, "Field to set as Name=Value, friendly or reference name, empty value clears the field. Can be specified multiple times.")
+	setWorkItemsCmd.Flags().StringVarP(&setWorkItemsCmdFlagWiql, "wiql", "q", "", "WIQL query selecting work items to update")
+	setWorkItemsCmd.Flags().BoolVarP(&setWorkItemsCmdFlagYes, "yes", "y", false, "Apply changes without confirmation")
+
+	cobra.CheckErr(setWorkItemsCmd.MarkFlagRequired("field"))
+}
+
+// parseWorkItemIDs parses work item IDs from arguments, "-" argument means IDs separated by spaces,
+// commas or new lines are read from stdin.
+func parseWorkItemIDs(args []string) ([]int, error) {
+	var values []string
+	for _, arg := range args {
+		if arg != "-" {
+			values = append(values, arg)
+			continue
+		}
+
+		data, err := io.ReadAll(bufio.NewReader(os.Stdin))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, strings.FieldsFunc(string(data), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+		})...)
+	}
+
+	var workItemIDs []int
+	for _, value := range values {
+		workItemID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid work item ID '%s': %w", value, err)
+		}
+		workItemIDs = append(workItemIDs, workItemID)
+	}
+
+	return lo.Uniq(workItemIDs), nil
+}
+
+type workItemFieldChange struct {
+	field    string
+	oldValue any
+	newValue any
+}
+
+type workItemChanges struct {
+	workItem *workitemtracking.WorkItem
+	changes  []workItemFieldChange
+	err      error
+}
+
+func setWorkItemsCommand(ctx context.Context, workItemIDs []int, fromStdin bool) error {
+	fields, err := parseFieldAssignments(setWorkItemsCmdFlagFields)
+	if err != nil {
+		return err
+	}
+
+	if fromStdin && !setWorkItemsCmdFlagYes {
+		return errors.New("confirmation is not possible when work item IDs are read from stdin, use --yes")
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	if setWorkItemsCmdFlagWiql != "" {
+		queried, err := a.WiClient.QueryIDsByWiql(ctx, setWorkItemsCmdFlagWiql)
+		if err != nil {
+			return err
+		}
+		workItemIDs = lo.Uniq(append(workItemIDs, queried...))
+	}
+
+	if len(workItemIDs) == 0 {
+		fmt.Println("no work items to update")
+		return nil
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading work items...")
+	workItems, err := a.WiClient.GetList(ctx, workItemIDs, append([]string{"System.Id", "System.Title"}, lo.Keys(fields)...))
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	items := lo.FilterMap(workItems, func(wi workitemtracking.WorkItem, _ int) (*workItemChanges, bool) {
+		item := &workItemChanges{workItem: &wi}
+		for _, field := range slices.Sorted(maps.Keys(fields)) {
+			oldValue := getWorkItemFieldValue(&wi, field)
+			newValue := fields[field]
+			if formatWorkItemFieldValue(oldValue) == formatWorkItemFieldValue(newValue) {
+				continue
+			}
+			item.changes = append(item.changes, workItemFieldChange{field: field, oldValue: oldValue, newValue: newValue})
+		}
+		return item, len(item.changes) > 0
+	})
+
+	if len(items) == 0 {
+		fmt.Println("nothing to change")
+		return nil
+	}
+
+	previewWorkItemChanges(items)
+
+	if !setWorkItemsCmdFlagYes {
+		ok, err := requestConfirmationKey()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("canceled by user")
+		}
+	}
+
+	applyWorkItemChanges(ctx, a, items)
+
+	return printWorkItemChangesSummary(items)
+}
+
+// parseFieldAssignments parses Name=Value pairs into reference names and values, empty value is nil.
+func parseFieldAssignments(assignments []string) (map[string]any, error) {
+	fields := make(map[string]any)
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field '%s', expected Name=Value", assignment)
+		}
+
+		if value == "" {
+			fields[getWorkItemFieldReferenceName(name)] = nil
+		} else {
+			fields[getWorkItemFieldReferenceName(name)] = value
+		}
+	}
+	return fields, nil
+}
+
+func previewWorkItemChanges(items []*workItemChanges) {
+	tableData := [][]string{{"ID", "Title", "Field", "Change"}}
+	for _, item := range items {
+		for i, change := range item.changes {
+			id, title := "", ""
+			if i == 0 {
+				id = strconv.Itoa(*item.workItem.Id)
+				title = cutString(workitem.GetTitle(item.workItem), 40, false)
+			}
+			tableData = append(tableData, []string{
+				id,
+				title,
+				change.field,
+				fmt.Sprintf("%s → %s", cutString(formatWorkItemFieldValue(change.oldValue), 30, false), cutString(formatWorkItemFieldValue(change.newValue), 30, false)),
+			})
+		}
+	}
+
+	_ = pterm.DefaultTable.
+		WithHasHeader().
+		WithData(tableData).
+		Render()
+}
+
+func applyWorkItemChanges(ctx context.Context, api *tfs.API, items []*workItemChanges) {
+	progressbar, _ := pterm.DefaultProgressbar.WithTitle("Updating...").WithTotal(len(items)).WithRemoveWhenDone().Start()
+
+	wg, _ := errgroup.WithContext(ctx)
+	var m sync.Mutex
+	guard := make(chan struct{}, 10)
+	for _, item := range items {
+		wg.Go(func() error {
+			guard <- struct{}{}
+			defer func() {
+				<-guard
+			}()
+
+			fields := lo.SliceToMap(item.changes, func(change workItemFieldChange) (string, any) {
+				return change.field, change.newValue
+			})
+
+			_, item.err = api.WiClient.UpdateFieldsAtRevision(ctx, *item.workItem.Id, *item.workItem.Rev, fields)
+
+			m.Lock()
+			if progressbar != nil {
+				progressbar.Increment()
+			}
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	_ = wg.Wait()
+	if progressbar != nil {
+		_, _ = progressbar.Stop()
+	}
+}
+
+func printWorkItemChangesSummary(items []*workItemChanges) error {
+	var failed int
+	for _, item := range items {
+		if item.err != nil {
+			failed++
+			pterm.Error.Println(fmt.Sprintf("NOT UPDATED %d: %s", *item.workItem.Id, item.err.Error()))
+		} else {
+			pterm.Success.Println(fmt.Sprintf("UPDATED %d %s", *item.workItem.Id, workitem.GetTitle(item.workItem)))
+		}
+	}
+
+	pterm.Info.Println(fmt.Sprintf("updated: %d, failed: %d", len(items)-failed, failed))
+	if failed > 0 {
+		return fmt.Errorf("%d work items not updated", failed)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Sprint reports",
+	Long:  `View reports on the team iteration: burndown etc.`,
+}
+
+func init() {
+	rootCmd.AddCommand(sprintCmd)
+}
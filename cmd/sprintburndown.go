@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
+	"tasker/wiki"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	burndownSprintCmd = &cobra.Command{
+		Use:   "burndown",
+		Short: "Sprint burndown chart",
+		Long: `Show remaining work of the iteration tasks per working day.
+Remaining work is computed by replaying revisions of tasks which are or were in the iteration.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := burndownSprintCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	burndownSprintCmdFlagIteration     string
+	burndownSprintCmdFlagOutput        string
+	burndownSprintCmdFlagPublishPageID uint
+)
+
+func init() {
+	sprintCmd.AddCommand(burndownSprintCmd)
+
+	burndownSprintCmd.Flags().StringVarP(&burndownSprintCmdFlagIteration, "iteration", "i", "", "Iteration name or path, previous, current or next (current by default)")
+	burndownSprintCmd.Flags().StringVarP(&burndownSprintCmdFlagOutput, "output", "o", outputFormatTable, "Output format (table, csv)")
+	burndownSprintCmd.Flags().UintVarP(&burndownSprintCmdFlagPublishPageID, "publish-page", "", 0, "ID of Wiki page to publish the chart to")
+}
+
+const burndownChartWidth = 50
+
+type sprintBurndownDay struct {
+	date      time.Time
+	remaining float64
+	ideal     float64
+	// future days have no remaining work yet
+	future bool
+}
+
+func burndownSprintCommand(ctx context.Context) error {
+	err := checkOutputFormat(burndownSprintCmdFlagOutput, outputFormatTable, outputFormatCSV)
+	if err != nil {
+		return err
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	iteration, err := a.GetIteration(ctx, burndownSprintCmdFlagIteration)
+	if err != nil {
+		return err
+	}
+
+	if iteration.Attributes == nil || iteration.Attributes.StartDate == nil || iteration.Attributes.FinishDate == nil {
+		return fmt.Errorf("iteration %s has no dates", *iteration.Name)
+	}
+
+	ids, err := a.WiClient.QueryIDs(ctx, wiql.Select("System.Id").
+		Where(
+			wiql.Eq("System.WorkItemType", "Task"),
+			wiql.Or(
+				wiql.Under("System.IterationPath", *iteration.Path),
+				wiql.Ever("System.IterationPath", *iteration.Path),
+			),
+		))
+	if err != nil {
+		return err
+	}
+
+	revisions, err := getWorkItemsRevisions(ctx, a, ids)
+	if err != nil {
+		return err
+	}
+
+	days := getSprintBurndown(iteration, revisions, time.Now())
+	tableData := [][]string{{"Date", "Remaining", "Ideal"}}
+	for _, day := range days {
+		tableData = append(tableData, []string{
+			day.date.Format(time.DateOnly),
+			lo.Ternary(day.future, "", strconv.FormatFloat(day.remaining, 'f', -1, 64)),
+			strconv.FormatFloat(math.Round(day.ideal*10)/10, 'f', -1, 64),
+		})
+	}
+
+	if burndownSprintCmdFlagOutput == outputFormatCSV {
+		err = printTable(outputFormatCSV, tableData)
+		if err != nil {
+			return err
+		}
+	} else {
+		pterm.DefaultSection.Println(fmt.Sprintf("%s burndown (%d tasks)", *iteration.Name, len(ids)))
+		renderSprintBurndown(days)
+	}
+
+	if burndownSprintCmdFlagPublishPageID != 0 {
+		wikiAPI, err := wiki.NewClient()
+		if err != nil {
+			return err
+		}
+
+		chart := wiki.ChartMacro(*iteration.Name+" burndown", "line", tableData)
+		err = wiki.UploadContent(wikiAPI, strconv.Itoa(int(burndownSprintCmdFlagPublishPageID)), chart, "storage")
+		if err != nil {
+			return err
+		}
+		pterm.Success.Println(fmt.Sprintf("PUBLISHED to page %d", burndownSprintCmdFlagPublishPageID))
+	}
+
+	return nil
+}
+
+func getWorkItemsRevisions(ctx context.Context, api *tfs.API, workItemIDs []int) (map[int][]workitemtracking.WorkItem, error) {
+	progressbar, _ := pterm.DefaultProgressbar.WithTitle("Loading revisions...").WithTotal(len(workItemIDs)).WithRemoveWhenDone().Start()
+
+	revisions := make(map[int][]workitemtracking.WorkItem)
+	wg, wgCtx := errgroup.WithContext(ctx)
+	var m sync.Mutex
+	guard := make(chan struct{}, 10)
+	for _, id := range workItemIDs {
+		wg.Go(func() error {
+			guard <- struct{}{}
+			defer func() {
+				<-guard
+			}()
+
+			workItemRevisions, err := api.WiClient.GetRevisions(wgCtx, id)
+			if err != nil {
+				return err
+			}
+
+			m.Lock()
+			revisions[id] = workItemRevisions
+			if progressbar != nil {
+				progressbar.Increment()
+			}
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	err := wg.Wait()
+	if progressbar != nil {
+		_, _ = progressbar.Stop()
+	}
+
+	return revisions, err
+}
+
+// getSprintBurndown returns remaining work of the tasks at the end of each working day of the iteration,
+// a task is counted while its revision at that moment is under the iteration path and not removed.
+func getSprintBurndown(iteration *work.TeamSettingsIteration, revisions map[int][]workitemtracking.WorkItem, now time.Time) []sprintBurndownDay {
+	var days []sprintBurndownDay
+	for date := iteration.Attributes.StartDate.Time; !date.After(iteration.Attributes.FinishDate.Time); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+
+		day := sprintBurndownDay{date: date, future: date.After(now)}
+		if !day.future {
+			end := date.AddDate(0, 0, 1)
+			for _, workItemRevisions := range revisions {
+				day.remaining += getRemainingWorkAt(workItemRevisions, *iteration.Path, end)
+			}
+		}
+		days = append(days, day)
+	}
+
+	if len(days) > 0 {
+		total := days[0].remaining
+		for i := range days {
+			if len(days) > 1 {
+				days[i].ideal = total * float64(len(days)-1-i) / float64(len(days)-1)
+			}
+		}
+	}
+
+	return days
+}
+
+func getRemainingWorkAt(revisions []workitemtracking.WorkItem, iterationPath string, moment time.Time) float64 {
+	var revision *workitemtracking.WorkItem
+	for i := range revisions {
+		if !workitem.GetChangedDate(&revisions[i]).Before(moment) {
+			break
+		}
+		revision = &revisions[i]
+	}
+
+	if revision == nil || workitem.GetState(revision) == "Removed" {
+		return 0
+	}
+
+	path := workitem.GetIterationPath(revision)
+	if path != iterationPath && !strings.HasPrefix(path, iterationPath+`\`) {
+		return 0
+	}
+
+	return float64(workitem.GetRemainingWork(revision))
+}
+
+func renderSprintBurndown(days []sprintBurndownDay) {
+	maxValue := lo.Max(lo.FlatMap(days, func(day sprintBurndownDay, _ int) []float64 {
+		return []float64{day.remaining, day.ideal}
+	}))
+	scale := func(value float64) int {
+		if maxValue == 0 {
+			return 0
+		}
+		return int(math.Round(value / maxValue * burndownChartWidth))
+	}
+
+	for _, day := range days {
+		bar := []rune(strings.Repeat(" ", burndownChartWidth+1))
+		if !day.future {
+			for i := range scale(day.remaining) {
+				bar[i] = '█'
+			}
+		}
+		bar[scale(day.ideal)] = '|'
+
+		remaining := lo.Ternary(day.future, "", strconv.FormatFloat(day.remaining, 'f', -1, 64))
+		fmt.Printf("%s %6s %s\n", day.date.Format("Mon 02.01"), remaining, strings.TrimRight(string(bar), " "))
+	}
+
+	pterm.Info.Println("█ remaining work, | ideal trend")
+}
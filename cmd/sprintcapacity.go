@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/tfs"
+	"tasker/tfs/work"
+	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
+	"tasker/wiki"
+
+	azurework "github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capacitySprintCmd = &cobra.Command{
+		Use:   "capacity",
+		Short: "Sprint capacity versus planned work",
+		Long: `Show capacity of team members in the iteration (without team and personal days off)
+and Remaining Work of not closed tasks assigned to them.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := capacitySprintCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	capacitySprintCmdFlagIteration string
+)
+
+func init() {
+	sprintCmd.AddCommand(capacitySprintCmd)
+
+	capacitySprintCmd.Flags().StringVarP(&capacitySprintCmdFlagIteration, "iteration", "i", "", "Iteration name or path, previous, current or next (current by default)")
+}
+
+// sprintMemberLoad is the planned work of the team member (or of not team member assignee without capacity).
+type sprintMemberLoad struct {
+	name     string
+	capacity *work.MemberCapacity
+	planned  float32
+}
+
+func (l *sprintMemberLoad) overloaded() bool {
+	return l.capacity != nil && l.planned > l.capacity.Capacity
+}
+
+func (l *sprintMemberLoad) utilization() string {
+	if l.capacity == nil || l.capacity.Capacity == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", l.planned/l.capacity.Capacity*100)
+}
+
+func capacitySprintCommand(ctx context.Context) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	iteration, err := a.GetIteration(ctx, capacitySprintCmdFlagIteration)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading...")
+	loads, err := getSprintMemberLoads(ctx, a, iteration)
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	pterm.DefaultSection.Println(fmt.Sprintf("%s capacity", *iteration.Name))
+
+	tableData := [][]string{{"Member", "Days", "Per day", "Capacity", "Planned", "Utilization"}}
+	var totalCapacity, totalPlanned float32
+	for _, load := range loads {
+		days, perDay, capacity := "", "", ""
+		if load.capacity != nil {
+			days = strconv.Itoa(load.capacity.WorkingDays)
+			perDay = fmt.Sprintf("%g", load.capacity.CapacityPerDay)
+			capacity = fmt.Sprintf("%g", load.capacity.Capacity)
+			totalCapacity += load.capacity.Capacity
+		}
+		totalPlanned += load.planned
+
+		row := []string{load.name, days, perDay, capacity, fmt.Sprintf("%g", load.planned), load.utilization()}
+		if load.overloaded() {
+			for i := range row {
+				row[i] = pterm.Red(row[i])
+			}
+		}
+		tableData = append(tableData, row)
+	}
+	tableData = append(tableData, []string{"∑", "", "", fmt.Sprintf("%g", totalCapacity), fmt.Sprintf("%g", totalPlanned), ""})
+
+	_ = pterm.DefaultTable.
+		WithHasHeader().
+		WithData(tableData).
+		Render()
+
+	for _, load := range loads {
+		if load.overloaded() {
+			pterm.Warning.Println(fmt.Sprintf("%s is over-allocated by %gh", load.name, load.planned-load.capacity.Capacity))
+		}
+	}
+
+	return nil
+}
+
+// getSprintMemberLoads returns team members capacities with Remaining Work of not closed tasks assigned to them,
+// assignees without capacity and unassigned work are listed after team members.
+func getSprintMemberLoads(ctx context.Context, api *tfs.API, iteration *azurework.TeamSettingsIteration) ([]*sprintMemberLoad, error) {
+	capacities, err := api.GetMemberCapacities(ctx, iteration)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := api.WiClient.QueryIDs(ctx, wiql.Select("System.Id").
+		Where(
+			wiql.Eq("System.WorkItemType", "Task"),
+			wiql.Under("System.IterationPath", *iteration.Path),
+			wiql.NotIn("System.State", "Closed", "Removed"),
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []workitemtracking.WorkItem
+	if len(ids) > 0 {
+		tasks, err = api.WiClient.GetList(ctx, ids, []string{"System.Id", "System.AssignedTo", "Microsoft.VSTS.Scheduling.RemainingWork"})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var loads []*sprintMemberLoad
+	for _, capacity := range capacities {
+		loads = append(loads, &sprintMemberLoad{name: capacity.DisplayName, capacity: &capacity})
+	}
+
+	var others []*sprintMemberLoad
+	for _, task := range tasks {
+		assignedTo := getWorkItemFieldValue(&task, "System.AssignedTo")
+		load := findSprintMemberLoad(loads, getIdentityNames(assignedTo)...)
+		if load == nil {
+			name := formatWorkItemFieldValue(assignedTo)
+			if name == "" {
+				name = "Unassigned"
+			}
+			load = findSprintMemberLoad(others, name)
+			if load == nil {
+				load = &sprintMemberLoad{name: name}
+				others = append(others, load)
+			}
+		}
+		load.planned += workitem.GetRemainingWork(&task)
+	}
+
+	slices.SortFunc(others, func(a, b *sprintMemberLoad) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
+	return append(loads, others...), nil
+}
+
+// getIdentityNames returns display and unique names of the identity field value.
+func getIdentityNames(value any) []string {
+	identity, ok := value.(map[string]any)
+	if !ok {
+		if s, ok := value.(string); ok {
+			return []string{s}
+		}
+		return nil
+	}
+
+	var names []string
+	for _, key := range []string{"displayName", "uniqueName"} {
+		if name, ok := identity[key].(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// findSprintMemberLoad finds the load of the member matching any of the names: display name,
+// unique name or account name without domain.
+func findSprintMemberLoad(loads []*sprintMemberLoad, names ...string) *sprintMemberLoad {
+	for _, load := range loads {
+		candidates := []string{load.name}
+		if load.capacity != nil {
+			_, account, _ := strings.Cut(load.capacity.UniqueName, `\`)
+			candidates = append(candidates, load.capacity.UniqueName, account)
+		}
+
+		for _, name := range names {
+			if slices.ContainsFunc(candidates, func(candidate string) bool {
+				return candidate != "" && strings.EqualFold(candidate, strings.TrimSpace(name))
+			}) {
+				return load
+			}
+		}
+	}
+	return nil
+}
+
+// checkSprintCapacity warns about team members over-allocated by the new tasks estimates
+// in the iteration and returns false if the user canceled.
+func checkSprintCapacity(ctx context.Context, api *tfs.API, iteration *azurework.TeamSettingsIteration, tasks []*wiki.Task) (bool, error) {
+	tasks = lo.Filter(tasks, func(t *wiki.Task, _ int) bool {
+		return t.TfsTaskID == 0 && strings.TrimSpace(t.AssignedTo) != ""
+	})
+	if len(tasks) == 0 {
+		return true, nil
+	}
+
+	loads, err := getSprintMemberLoads(ctx, api, iteration)
+	if err != nil {
+		pterm.Warning.Println(fmt.Sprintf("capacity not checked: %s", err.Error()))
+		return true, nil
+	}
+
+	var overloaded []*sprintMemberLoad
+	for _, t := range tasks {
+		load := findSprintMemberLoad(loads, t.AssignedTo)
+		if load == nil || load.capacity == nil {
+			continue
+		}
+
+		load.planned += t.Estimate
+		if load.overloaded() && !slices.Contains(overloaded, load) {
+			overloaded = append(overloaded, load)
+		}
+	}
+
+	if len(overloaded) == 0 {
+		return true, nil
+	}
+
+	for _, load := range overloaded {
+		pterm.Warning.Println(fmt.Sprintf("%s will be over-allocated in %s: planned %gh, capacity %gh (%s)",
+			load.name, *iteration.Name, load.planned, load.capacity.Capacity, load.utilization()))
+	}
+
+	return requestConfirmationKey()
+}
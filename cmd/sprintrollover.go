@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
+
+	azurework "github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	rolloverSprintCmd = &cobra.Command{
+		Use:   "rollover",
+		Short: "Move unfinished work into the next iteration",
+		Long: `Move not closed work items of the team areas from one iteration into another.
+With --split partially done tasks are closed with their completed work and the remaining work is moved into a new task.`,
+		Example: `  tasker sprint rollover
+  tasker sprint rollover --from "Sprint 41" --to next --split --tag rollover`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := rolloverSprintCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	rolloverSprintCmdFlagFrom  string
+	rolloverSprintCmdFlagTo    string
+	rolloverSprintCmdFlagSplit bool
+	rolloverSprintCmdFlagTag   string
+	rolloverSprintCmdFlagYes   bool
+)
+
+func init() {
+	sprintCmd.AddCommand(rolloverSprintCmd)
+
+	rolloverSprintCmd.Flags().StringVarP(&rolloverSprintCmdFlagFrom, "from", "", "previous", "Source iteration name or path, previous, current or next")
+	rolloverSprintCmd.Flags().StringVarP(&rolloverSprintCmdFlagTo, "to", "", "current", "Target iteration name or path, previous, current or next")
+	rolloverSprintCmd.Flags().BoolVarP(&rolloverSprintCmdFlagSplit, "split", "s", false, "Split partially done tasks into the closed part and a new task with remaining work")
+	rolloverSprintCmd.Flags().StringVarP(&rolloverSprintCmdFlagTag, "tag", "t", "", "Tag added to rolled over work items")
+	rolloverSprintCmd.Flags().BoolVarP(&rolloverSprintCmdFlagYes, "yes", "y", false, "Move work items without confirmation")
+}
+
+// rolloverDoneStates are states of work items which are not rolled over.
+var rolloverDoneStates = []string{"Closed", "Removed", "Done"}
+
+type rolloverItem struct {
+	workItem *workitemtracking.WorkItem
+	split    bool
+	// remainderID is the ID of the new task with remaining work of the split task
+	remainderID int
+	err         error
+}
+
+func rolloverSprintCommand(ctx context.Context) error {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	from, err := a.GetIteration(ctx, rolloverSprintCmdFlagFrom)
+	if err != nil {
+		return err
+	}
+
+	to, err := a.GetIteration(ctx, rolloverSprintCmdFlagTo)
+	if err != nil {
+		return err
+	}
+
+	if *from.Path == *to.Path {
+		return fmt.Errorf("source and target iterations are the same: %s", *from.Path)
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading work items...")
+	items, err := getRolloverItems(ctx, a, from)
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("no unfinished work items in %s\n", *from.Name)
+		return nil
+	}
+
+	pterm.DefaultSection.Println(fmt.Sprintf("%s → %s", *from.Path, *to.Path))
+	previewRolloverItems(items)
+
+	if !rolloverSprintCmdFlagYes {
+		ok, err := requestConfirmationKey()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("canceled by user")
+		}
+	}
+
+	applyRollover(ctx, a, items, to)
+
+	return printRolloverSummary(items, to)
+}
+
+func getRolloverItems(ctx context.Context, api *tfs.API, from *azurework.TeamSettingsIteration) ([]*rolloverItem, error) {
+	teamArea, err := api.GetTeamAreaCondition(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := api.WiClient.QueryIDs(ctx, wiql.Select("System.Id").
+		Where(
+			wiql.Under("System.IterationPath", *from.Path),
+			teamArea,
+			wiql.NotIn("System.State", rolloverDoneStates...),
+		).
+		OrderBy("System.Id"))
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	workItems, err := api.WiClient.GetList(ctx, ids, []string{
+		"System.Id",
+		"System.WorkItemType",
+		"System.Title",
+		"System.State",
+		"System.AssignedTo",
+		"System.Tags",
+		"Microsoft.VSTS.Scheduling.CompletedWork",
+		"Microsoft.VSTS.Scheduling.RemainingWork",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(workItems, func(wi workitemtracking.WorkItem, _ int) *rolloverItem {
+		split := rolloverSprintCmdFlagSplit &&
+			workitem.GetType(&wi) == "Task" &&
+			workitem.GetCompletedWork(&wi) > 0 &&
+			workitem.GetRemainingWork(&wi) > 0
+		return &rolloverItem{workItem: &wi, split: split}
+	}), nil
+}
+
+func previewRolloverItems(items []*rolloverItem) {
+	tableData := [][]string{{"ID", "Type", "Title", "State", "Assigned To", "Completed", "Remaining", "Action"}}
+	for _, item := range items {
+		wi := item.workItem
+		tableData = append(tableData, []string{
+			strconv.Itoa(*wi.Id),
+			workitem.GetType(wi),
+			cutString(workitem.GetTitle(wi), 40, false),
+			workitem.GetState(wi),
+			formatWorkItemFieldValue(getWorkItemFieldValue(wi, "System.AssignedTo")),
+			fmt.Sprintf("%g", workitem.GetCompletedWork(wi)),
+			fmt.Sprintf("%g", workitem.GetRemainingWork(wi)),
+			lo.Ternary(item.split, "split", "move"),
+		})
+	}
+
+	_ = pterm.DefaultTable.
+		WithHasHeader().
+		WithData(tableData).
+		Render()
+}
+
+func applyRollover(ctx context.Context, api *tfs.API, items []*rolloverItem, to *azurework.TeamSettingsIteration) {
+	progressbar, _ := pterm.DefaultProgressbar.WithTitle("Moving...").WithTotal(len(items)).WithRemoveWhenDone().Start()
+
+	wg, _ := errgroup.WithContext(ctx)
+	var m sync.Mutex
+	guard := make(chan struct{}, 10)
+	for _, item := range items {
+		wg.Go(func() error {
+			guard <- struct{}{}
+			defer func() {
+				<-guard
+			}()
+
+			if item.split {
+				item.err = splitRolloverTask(ctx, api, item, to)
+			} else {
+				item.err = moveRolloverWorkItem(ctx, api, item, to)
+			}
+
+			m.Lock()
+			if progressbar != nil {
+				progressbar.Increment()
+			}
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	_ = wg.Wait()
+	if progressbar != nil {
+		_, _ = progressbar.Stop()
+	}
+}
+
+func getRolloverTags(wi *workitemtracking.WorkItem) []string {
+	tags := lo.Compact(workitem.GetTags(wi))
+	if rolloverSprintCmdFlagTag != "" {
+		tags = lo.Uniq(append(tags, rolloverSprintCmdFlagTag))
+	}
+	return tags
+}
+
+func moveRolloverWorkItem(ctx context.Context, api *tfs.API, item *rolloverItem, to *azurework.TeamSettingsIteration) error {
+	fields := map[string]any{"System.IterationPath": *to.Path}
+	if rolloverSprintCmdFlagTag != "" {
+		fields["System.Tags"] = strings.Join(getRolloverTags(item.workItem), "; ")
+	}
+
+	_, err := api.WiClient.UpdateFieldsAtRevision(ctx, *item.workItem.Id, *item.workItem.Rev, fields)
+	return err
+}
+
+// splitRolloverTask creates the task with remaining work in the target iteration under the same parent
+// and closes the source task with its completed work.
+func splitRolloverTask(ctx context.Context, api *tfs.API, item *rolloverItem, to *azurework.TeamSettingsIteration) error {
+	source, err := api.WiClient.GetExpanded(ctx, *item.workItem.Id)
+	if err != nil {
+		return err
+	}
+
+	relations := []*workitem.Relation{
+		{
+			URL:     *source.Url,
+			Type:    "System.LinkTypes.Related",
+			Comment: fmt.Sprintf("Split from #%d", *source.Id),
+		},
+	}
+	if source.Relations != nil {
+		for _, relation := range *source.Relations {
+			if *relation.Rel == "System.LinkTypes.Hierarchy-Reverse" {
+				relations = append(relations, &workitem.Relation{URL: *relation.Url, Type: *relation.Rel})
+			}
+		}
+	}
+
+	remainingWork := workitem.GetRemainingWork(source)
+	remainder, err := api.WiClient.Copy(ctx, source, workitem.GetAreaPath(source), *to.Path, relations, getRolloverTags(source),
+		workitem.CopyResetState(true),
+		workitem.CopyOnlyFields(
+			"System.Title",
+			"System.Description",
+			"System.AssignedTo",
+			"Microsoft.VSTS.Common.Priority",
+			"Microsoft.VSTS.Common.Activity",
+		),
+		workitem.CopyFields(map[string]any{
+			"Microsoft.VSTS.Scheduling.OriginalEstimate": remainingWork,
+			"Microsoft.VSTS.Scheduling.RemainingWork":    remainingWork,
+		}))
+	if err != nil {
+		return err
+	}
+	item.remainderID = *remainder.Id
+
+	// the completed part is closed as is, without the default rules adding remaining work to completed work
+	rules := []workItemTransitionRule{
+		{
+			State:  "Closed",
+			Fields: []workItemTransitionField{{Name: "RemainingWork", Value: "0"}},
+		},
+	}
+	err = transitionWorkItem(ctx, api, &workItemTransitionResult{workItemID: *source.Id}, "Closed", workItemTransitionOptions{rules: rules})
+	if err != nil {
+		return fmt.Errorf("remainder %d created, but task not closed: %w", item.remainderID, err)
+	}
+
+	return nil
+}
+
+func printRolloverSummary(items []*rolloverItem, to *azurework.TeamSettingsIteration) error {
+	var failed int
+	for _, item := range items {
+		id, title := *item.workItem.Id, workitem.GetTitle(item.workItem)
+		switch {
+		case item.err != nil:
+			failed++
+			pterm.Error.Println(fmt.Sprintf("NOT MOVED %d %s: %s", id, title, item.err.Error()))
+		case item.split:
+			pterm.Success.Println(fmt.Sprintf("SPLIT %d %s: closed, remaining work moved to %d", id, title, item.remainderID))
+		default:
+			pterm.Success.Println(fmt.Sprintf("MOVED %d %s", id, title))
+		}
+	}
+
+	pterm.Info.Println(fmt.Sprintf("moved to %s: %d, failed: %d", *to.Name, len(items)-failed, failed))
+	if failed > 0 {
+		return fmt.Errorf("%d work items not moved", failed)
+	}
+	return nil
+}
@@ -14,10 +14,13 @@ import (
 
 	"tasker/tasksui"
 	"tasker/tfs"
+	"tasker/tfs/work"
+	"tasker/tfs/workitem"
 	"tasker/wiki"
 
 	"github.com/eiannone/keyboard"
 	"github.com/google/uuid"
+	azurework "github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
 	"github.com/pterm/pterm"
 	"github.com/samber/lo"
@@ -53,6 +56,7 @@ var (
 	syncCmdFlagTags              []string
 	syncCmdFlagPartNumber        uint32
 	syncCmdFlagAppendTagsToTitle bool
+	syncCmdFlagNoCapacityCheck   bool
 
 	syncCmdTemplatesCache = make(map[string]*template.Template)
 )
@@ -68,6 +72,7 @@ func init() {
 	syncCmd.Flags().StringSliceVarP(&syncCmdFlagTags, "tag", "t", []string{"tasker"}, "Tags of the tasks. Can be separated by comma or specified multiple times.")
 	syncCmd.Flags().Uint32VarP(&syncCmdFlagPartNumber, "part", "p", 0, "Table number (tasks part), if tasks splitted into multiple tables (parts)")
 	syncCmd.Flags().BoolVar(&syncCmdFlagAppendTagsToTitle, "append-tags-to-title", false, "Append tas tags to task title")
+	syncCmd.Flags().BoolVar(&syncCmdFlagNoCapacityCheck, "no-capacity-check", false, "Do not warn about assignees over-allocated in the sprint")
 }
 
 func syncCommand(ctx context.Context, wikiPageID int) error {
@@ -149,6 +154,13 @@ func syncCommand(ctx context.Context, wikiPageID int) error {
 		return err
 	}
 
+	if ok && !syncCmdFlagNoCapacityCheck {
+		ok, err = checkSyncCapacity(ctx, int(syncCmdFlagFeatureWorkItemID), tasks)
+		if err != nil {
+			return err
+		}
+	}
+
 	if ok {
 		err = createTasks(ctx, int(syncCmdFlagFeatureWorkItemID), tasks)
 		if err != nil {
@@ -161,6 +173,48 @@ func syncCommand(ctx context.Context, wikiPageID int) error {
 	return err
 }
 
+func checkSyncCapacity(ctx context.Context, featureID int, tasks []*wiki.Task) (bool, error) {
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	feature, err := a.WiClient.Get(ctx, featureID)
+	if err != nil {
+		return false, err
+	}
+
+	iterations, err := work.GetIterations(ctx, a.Conn, a.Project, a.Team)
+	if err != nil {
+		pterm.Warning.Println(fmt.Sprintf("capacity not checked: %s", err.Error()))
+		return true, nil
+	}
+
+	// tasks are created in the iteration of the feature
+	iterationPath := workitem.GetIterationPath(feature)
+	iteration := findSyncSprint(iterations, iterationPath)
+	if iteration == nil {
+		pterm.Warning.Println(fmt.Sprintf("capacity not checked: no team sprint at or under %s", iterationPath))
+		return true, nil
+	}
+
+	return checkSprintCapacity(ctx, a, iteration, tasks)
+}
+
+// findSyncSprint returns the team sprint of the iteration path: the iteration itself if it is a team sprint,
+// otherwise the current team sprint under it (the feature is usually planned for a release node).
+func findSyncSprint(iterations *[]azurework.TeamSettingsIteration, iterationPath string) *azurework.TeamSettingsIteration {
+	if iteration := work.FindIteration(iterations, iterationPath); iteration != nil {
+		return iteration
+	}
+
+	current := work.FindCurrentIteration(iterations)
+	if current != nil && strings.HasPrefix(strings.ToLower(*current.Path), strings.ToLower(iterationPath)+`\`) {
+		return current
+	}
+	return nil
+}
+
 func filterTasks(tasks []*wiki.Task, predicate func(*wiki.Task) bool) []*wiki.Task {
 	var filtered []*wiki.Task
 	for _, t := range tasks {
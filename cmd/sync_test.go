@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	azurework "github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findSyncSprint(t *testing.T) {
+	newIteration := func(path, timeFrame string) azurework.TeamSettingsIteration {
+		return azurework.TeamSettingsIteration{
+			Name:       &path,
+			Path:       &path,
+			Attributes: &azurework.TeamIterationAttributes{TimeFrame: (*azurework.TimeFrame)(&timeFrame)},
+		}
+	}
+	iterations := &[]azurework.TeamSettingsIteration{
+		newIteration(`Project\Release 1\Sprint 1`, "past"),
+		newIteration(`Project\Release 2\Sprint 2`, "current"),
+		newIteration(`Project\Release 2\Sprint 3`, "future"),
+	}
+
+	tests := []struct {
+		name          string
+		iterationPath string
+		want          string
+	}{
+		{name: "team sprint", iterationPath: `Project\Release 2\Sprint 3`, want: `Project\Release 2\Sprint 3`},
+		{name: "release with current sprint", iterationPath: `Project\Release 2`, want: `Project\Release 2\Sprint 2`},
+		{name: "release without current sprint", iterationPath: `Project\Release 1`},
+		{name: "path prefix is not parent", iterationPath: `Project\Release`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iteration := findSyncSprint(iterations, tt.iterationPath)
+			if tt.want == "" {
+				assert.Nil(t, iteration)
+				return
+			}
+			if assert.NotNil(t, iteration) {
+				assert.Equal(t, tt.want, *iteration.Path)
+			}
+		})
+	}
+}
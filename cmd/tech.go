@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"tasker/tasksui"
 	"tasker/tfs"
+	"tasker/tfs/workitem"
 	"tasker/wiki"
 
 	"github.com/eiannone/keyboard"
@@ -17,6 +19,7 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	goconfluence "github.com/virtomize/confluence-go-api"
 	"golang.org/x/sync/errgroup"
 )
@@ -49,7 +52,17 @@ var (
 		},
 	}
 
-	techDebtPriorityRegexp   = regexp.MustCompile(`\d+`)
+	unarchiveTechCmd = &cobra.Command{
+		Use:   "unarchive",
+		Short: "Unarchive reopened tech debt tasks",
+		Long:  `Move archived technical debt tasks with reopened or new active work items back under parent page.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := unarchiveTechCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	techDebtPriorityRegexp   = regexp.MustCompile(`^\s*(\d+)\.\s*`)
 	techDebtEstimationRegexp = regexp.MustCompile(`\[\d+\]`)
 
 	syncTechCmdFlagTfsRequirementID    uint
@@ -60,15 +73,28 @@ var (
 	syncTechCmdFlagTfsWorkItemPrefix   string
 	syncTechCmdFlagTfsEstimate         uint
 	syncTechCmdFlagTfsDefaultPriority  uint
+	syncTechCmdFlagUpdate              bool
+	syncTechCmdFlagDepth               int
+	syncTechCmdFlagLabels              []string
+	syncTechCmdFlagCQL                 string
+	syncTechCmdFlagDuplicateThreshold  float64
+	syncTechCmdFlagLinkDuplicates      bool
 
 	archiveTechCmdFlagWikiParentPageID  uint
 	archiveTechCmdFlagWikiArchivePageID uint
+	archiveTechCmdFlagDepth             int
+	archiveTechCmdFlagLabels            []string
+	archiveTechCmdFlagCQL               string
+
+	unarchiveTechCmdFlagWikiParentPageID  uint
+	unarchiveTechCmdFlagWikiArchivePageID uint
 )
 
 func init() {
 	rootCmd.AddCommand(techCmd)
 	techCmd.AddCommand(syncTechCmd)
 	techCmd.AddCommand(archiveTechCmd)
+	techCmd.AddCommand(unarchiveTechCmd)
 
 	syncTechCmd.Flags().UintVarP(&syncTechCmdFlagTfsRequirementID, "requirement", "r", 0, "The ID of Parent TFS requirement/feature work item for Tech Debt tasks")
 	syncTechCmd.Flags().UintVarP(&syncTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "The ID of Wiki parent page with Tech Debt tasks")
@@ -78,21 +104,43 @@ func init() {
 	syncTechCmd.Flags().StringVarP(&syncTechCmdFlagTfsWorkItemPrefix, "prefix", "", "[SMP] [tech]", "The prefix of work items")
 	syncTechCmd.Flags().UintVarP(&syncTechCmdFlagTfsEstimate, "estimate", "e", 16, "The default estimate")
 	syncTechCmd.Flags().UintVarP(&syncTechCmdFlagTfsDefaultPriority, "priority", "", 1, "The work item default priority")
+	syncTechCmd.Flags().BoolVarP(&syncTechCmdFlagUpdate, "update", "u", false, "Update work items already linked to the wiki pages if the pages were changed")
+	syncTechCmd.Flags().IntVarP(&syncTechCmdFlagDepth, "depth", "", 1, "Depth of Tech Debt pages search under parent page (0 - unlimited)")
+	syncTechCmd.Flags().StringSliceVarP(&syncTechCmdFlagLabels, "label", "l", nil, "Only Tech Debt pages with any of specified labels")
+	syncTechCmd.Flags().StringVarP(&syncTechCmdFlagCQL, "cql", "", "", "Only Tech Debt pages matching CQL query")
+	syncTechCmd.Flags().Float64VarP(&syncTechCmdFlagDuplicateThreshold, "duplicate-threshold", "", 0.6, "Similarity score (0..1) from which pages are considered duplicates")
+	syncTechCmd.Flags().BoolVarP(&syncTechCmdFlagLinkDuplicates, "link-duplicates", "", false, "Link pages to existing duplicate work items instead of creating new ones")
 
 	cobra.CheckErr(syncTechCmd.MarkFlagRequired("requirement"))
 
 	archiveTechCmd.Flags().UintVarP(&archiveTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "ID of Wiki parent page with Tech Debt tasks")
 	archiveTechCmd.Flags().UintVarP(&archiveTechCmdFlagWikiArchivePageID, "archive-page", "a", 0, "ID of Wiki archive page with completed Tech Debt tasks")
+	archiveTechCmd.Flags().IntVarP(&archiveTechCmdFlagDepth, "depth", "", 1, "Depth of Tech Debt pages search under parent page (0 - unlimited)")
+	archiveTechCmd.Flags().StringSliceVarP(&archiveTechCmdFlagLabels, "label", "l", nil, "Only Tech Debt pages with any of specified labels")
+	archiveTechCmd.Flags().StringVarP(&archiveTechCmdFlagCQL, "cql", "", "", "Only Tech Debt pages matching CQL query")
 
 	cobra.CheckErr(archiveTechCmd.MarkFlagRequired("parent-page"))
 	cobra.CheckErr(archiveTechCmd.MarkFlagRequired("archive-page"))
+
+	unarchiveTechCmd.Flags().UintVarP(&unarchiveTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "ID of Wiki parent page with Tech Debt tasks")
+	unarchiveTechCmd.Flags().UintVarP(&unarchiveTechCmdFlagWikiArchivePageID, "archive-page", "a", 0, "ID of Wiki archive page with completed Tech Debt tasks")
+
+	cobra.CheckErr(unarchiveTechCmd.MarkFlagRequired("parent-page"))
+	cobra.CheckErr(unarchiveTechCmd.MarkFlagRequired("archive-page"))
 }
 
 type techDebtPage struct {
 	*wiki.TechDebt
-	content  *goconfluence.Content
-	estimate float32
-	priority float32
+	content         *goconfluence.Content
+	estimate        float32
+	priority        float32
+	workItemID      int
+	linkedIDs       []int
+	duplicate       *techDebtDuplicate
+	tags            []string
+	areaPath        string
+	requirementType string
+	assignedTo      string
 }
 
 func (t *techDebtPage) GetTitle() string                  { return t.Title }
@@ -103,16 +151,39 @@ func (t *techDebtPage) GetEstimate() float32              { return t.estimate }
 func (t *techDebtPage) SetEstimate(estimate float32)      { t.estimate = estimate }
 func (t *techDebtPage) GetPriority() float32              { return t.priority }
 func (t *techDebtPage) SetPriority(priority float32)      { t.priority = priority }
-func (t *techDebtPage) GetTfsTaskID() int                 { return 0 }
+func (t *techDebtPage) GetTfsTaskID() int                 { return t.workItemID }
 func (t *techDebtPage) SetTfsTaskID(_ int)                { /* not supported */ }
 func (t *techDebtPage) Clone() tasksui.Task {
 	t2 := *t
 	return &t2
 }
-func (t *techDebtPage) GetTags() []string         { return nil }
-func (t *techDebtPage) SetTags(tags []string)     { /* not supported */ }
-func (t *techDebtPage) GetTagsString() string     { return "" }
-func (t *techDebtPage) SetTagsString(tags string) { /* not supported */ }
+func (t *techDebtPage) GetTags() []string     { return t.tags }
+func (t *techDebtPage) SetTags(tags []string) { t.tags = tags }
+func (t *techDebtPage) GetTagsString() string { return strings.Join(t.tags, "; ") }
+func (t *techDebtPage) SetTagsString(tags string) {
+	t.tags = lo.Compact(lo.Map(strings.Split(tags, ";"), func(tag string, _ int) string {
+		return strings.TrimSpace(tag)
+	}))
+}
+func (t *techDebtPage) GetAnnotation() string {
+	annotation := []string{fmt.Sprintf("priority: %v", t.priority)}
+	if t.requirementType != "" && t.requirementType != defaultTechDebtRequirementType {
+		annotation = append(annotation, "type: "+t.requirementType)
+	}
+	if t.areaPath != "" {
+		annotation = append(annotation, "area: "+t.areaPath)
+	}
+	if t.assignedTo != "" {
+		annotation = append(annotation, "assigned: "+t.assignedTo)
+	}
+	if len(t.tags) > 0 {
+		annotation = append(annotation, "tags: "+t.GetTagsString())
+	}
+	if t.duplicate != nil {
+		annotation = append(annotation, t.duplicate.String())
+	}
+	return "(" + strings.Join(annotation, ", ") + ")"
+}
 
 type techDebtPageTable struct {
 	pages []*techDebtPage
@@ -136,14 +207,14 @@ func syncTechCommand(ctx context.Context) error {
 
 	var pageIDs []string
 	if wikiParentID != 0 {
-		searchResult, err := wikiAPI.GetChildPages(strconv.Itoa(wikiParentID))
+		pageIDs, err = getTechDebtPageIDs(wikiAPI, strconv.Itoa(wikiParentID),
+			wiki.DescendantsDepth(syncTechCmdFlagDepth),
+			wiki.DescendantsLabels(syncTechCmdFlagLabels...),
+			wiki.DescendantsCQL(syncTechCmdFlagCQL),
+		)
 		if err != nil {
 			return err
 		}
-
-		for _, page := range searchResult.Results {
-			pageIDs = append(pageIDs, page.ID)
-		}
 	} else {
 		for _, pageID := range syncTechCmdFlagWikiTechDebtPageIDs {
 			pageIDs = append(pageIDs, strconv.Itoa(int(pageID)))
@@ -155,13 +226,20 @@ func syncTechCommand(ctx context.Context) error {
 		return err
 	}
 
+	var linkedPages []*techDebtPage
+	if syncTechCmdFlagUpdate && !syncTechCmdFlagForceCreate {
+		linkedPages = lo.Filter(pages, func(item *techDebtPage, _ int) bool {
+			return len(item.TfsTasks) > 0 && !item.IsEmptyPage
+		})
+	}
+
 	if !syncTechCmdFlagForceCreate {
 		pages = lo.Filter(pages, func(item *techDebtPage, _ int) bool {
 			return len(item.TfsTasks) == 0 && !item.IsEmptyPage
 		})
 	}
 
-	if len(pages) == 0 {
+	if len(pages) == 0 && len(linkedPages) == 0 {
 		fmt.Println("nothing to create or update")
 		return nil
 	}
@@ -176,22 +254,51 @@ func syncTechCommand(ctx context.Context) error {
 		return err
 	}
 
-	uiTables := []tasksui.Table{
-		&techDebtPageTable{pages: pages},
+	state, err := loadTechDebtSyncState()
+	if err != nil {
+		return err
+	}
+
+	labelMapping, err := loadTechDebtLabelMapping()
+	if err != nil {
+		return err
 	}
 
-	for _, page := range pages {
-		page.Title, _ = strings.CutPrefix(page.Title, fmt.Sprintf("%v.", page.priority))
-		page.Title, _ = strings.CutSuffix(page.Title, fmt.Sprintf("[%v]", page.estimate))
-		page.Title = strings.TrimSpace(page.Title)
+	for _, page := range append(pages, linkedPages...) {
+		page.Title = getTechDebtWorkItemTitle(page, syncTechCmdFlagTfsWorkItemPrefix)
 	}
 
-	if syncTechCmdFlagTfsWorkItemPrefix != "" {
-		for _, page := range pages {
-			page.Title = fmt.Sprintf("%s %s", syncTechCmdFlagTfsWorkItemPrefix, page.Title)
+	applyTechDebtLabelMapping(append(pages, linkedPages...), labelMapping)
+
+	if len(pages) > 0 {
+		err = findTechDebtDuplicates(ctx, pages, tfsAPI, requirementID, syncTechCmdFlagDuplicateThreshold)
+		if err != nil {
+			return err
+		}
+
+		if syncTechCmdFlagLinkDuplicates {
+			linkTechDebtDuplicates(pages)
 		}
 	}
 
+	updatePages, err := getChangedTechDebtPages(ctx, linkedPages, tfsAPI, state)
+	if err != nil {
+		return err
+	}
+
+	if len(pages) == 0 && len(updatePages) == 0 {
+		fmt.Println("nothing to create or update")
+		return state.save()
+	}
+
+	var uiTables []tasksui.Table
+	if len(pages) > 0 {
+		uiTables = append(uiTables, &techDebtPageTable{pages: pages})
+	}
+	if len(updatePages) > 0 {
+		uiTables = append(uiTables, &techDebtPageTable{pages: updatePages})
+	}
+
 	ok, err := tasksui.PreviewTasks(uiTables)
 	if err != nil {
 		return err
@@ -201,33 +308,152 @@ func syncTechCommand(ctx context.Context) error {
 		return nil
 	}
 
-	_ = requirement
+	err = createTechDebtTasks(ctx, pages, tfsAPI, wikiAPI, requirement, state)
+	if err != nil {
+		return err
+	}
+
+	err = updateTechDebtTasks(ctx, updatePages, tfsAPI, state)
+	if err != nil {
+		return err
+	}
+
+	return state.save()
+}
+
+func getTechDebtWorkItemTitle(page *techDebtPage, prefix string) string {
+	title, _ := strings.CutPrefix(page.Title, fmt.Sprintf("%v.", page.priority))
+	title, _ = strings.CutSuffix(title, fmt.Sprintf("[%v]", page.estimate))
+	title = strings.TrimSpace(title)
+
+	if prefix != "" {
+		title = fmt.Sprintf("%s %s", prefix, title)
+	}
 
-	err = createTechDebtTasks(ctx, pages, tfsAPI, wikiAPI, requirement)
+	return title
+}
+
+// getChangedTechDebtPages returns linked pages which were edited since the last sync
+// or whose priority or estimate differ from the linked work item.
+func getChangedTechDebtPages(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs.API, state *techDebtSyncState) ([]*techDebtPage, error) {
+	if len(pages) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := getTechDebtTasks(ctx, pages, tfsAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*techDebtPage
+	for _, page := range pages {
+		linked := lo.FilterMap(page.TfsTasks, func(task wiki.TfsTask, _ int) (*workitemtracking.WorkItem, bool) {
+			return lo.Find(tasks[page.PageID], func(wi *workitemtracking.WorkItem) bool {
+				return *wi.Id == task.ItemID
+			})
+		})
+		if len(linked) == 0 {
+			continue
+		}
+
+		page.workItemID = *linked[0].Id
+		page.linkedIDs = lo.Map(linked, func(wi *workitemtracking.WorkItem, _ int) int { return *wi.Id })
+		version := page.content.Version.Number
+		fieldsChanged := lo.SomeBy(linked, func(wi *workitemtracking.WorkItem) bool {
+			return workitem.GetPriority(wi) != page.priority || workitem.GetOriginalEstimate(wi) != page.estimate
+		})
+
+		pageState, synced := state.Pages[page.PageID]
+		switch {
+		case !synced && !fieldsChanged && !lo.SomeBy(linked, func(wi *workitemtracking.WorkItem) bool {
+			return isTechDebtContentChanged(page, wi)
+		}):
+			// first sync of a page created before update mode, remember it as is
+			state.setPage(page.PageID, page.workItemID, version)
+		case !synced || pageState.Version != version || pageState.WorkItemID != page.workItemID || fieldsChanged:
+			changed = append(changed, page)
+		}
+	}
+
+	return changed, nil
+}
+
+// isTechDebtContentChanged reports whether title or text of description differ between the page and the work item.
+func isTechDebtContentChanged(page *techDebtPage, wi *workitemtracking.WorkItem) bool {
+	return workitem.GetTitle(wi) != page.Title ||
+		htmlToText(workitem.GetDescription(wi)) != htmlToText(page.Description)
+}
+
+// parseTechDebtTitlePriority returns priority from the title prefix like "12. Title".
+func parseTechDebtTitlePriority(title string) (float64, error) {
+	match := techDebtPriorityRegexp.FindStringSubmatch(title)
+	if match == nil {
+		return 0, fmt.Errorf("no priority in title '%s'", title)
+	}
+	return strconv.ParseFloat(match[1], 32)
+}
+
+func updateTechDebtTasks(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs.API, state *techDebtSyncState) error {
+	if len(pages) == 0 {
+		return nil
+	}
+
+	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(pages)).WithRemoveWhenDone().Start()
 	if err != nil {
 		return err
 	}
 
+	for _, page := range pages {
+		progressbar.UpdateTitle(fmt.Sprintf("Updating %s", cutString(page.Title, 20, true)))
+
+		var failed bool
+		for _, workItemID := range page.linkedIDs {
+			err = tfsAPI.WiClient.UpdateRequirement(ctx, workItemID, page.Title, page.Description, page.estimate, page.priority)
+			if err != nil {
+				failed = true
+				pterm.Error.Println(fmt.Sprintf("TFS Task %d NOT UPDATED %s: %s", workItemID, page.Title, err.Error()))
+			}
+		}
+
+		if !failed {
+			state.setPage(page.PageID, page.workItemID, page.content.Version.Number)
+			pterm.Success.Println(fmt.Sprintf("UPDATED %s", page.Title))
+		}
+
+		progressbar.Increment()
+	}
+	_, _ = progressbar.Stop()
 	return nil
 }
 
-func createTechDebtTasks(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs.API, wikiAPI *wiki.API, requirement *workitemtracking.WorkItem) error {
+func createTechDebtTasks(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs.API, wikiAPI *wiki.API, requirement *workitemtracking.WorkItem, state *techDebtSyncState) error {
+	if len(pages) == 0 {
+		return nil
+	}
+
 	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(pages)).WithRemoveWhenDone().Start()
 	if err != nil {
 		return err
 	}
 
 	for _, page := range pages {
+		if isLinkedTechDebtDuplicate(page) {
+			progressbar.UpdateTitle(fmt.Sprintf("Linking %s", cutString(page.Title, 20, true)))
+			linkTechDebtPage(wikiAPI, page, page.workItemID, state)
+			progressbar.Increment()
+			continue
+		}
+
 		progressbar.UpdateTitle(fmt.Sprintf("Creating %s", cutString(page.Title, 20, true)))
 		tags := []string{}
-		tags = append(tags, page.Labels...)
+		tags = append(tags, page.tags...)
 
 		var tfsTask *workitemtracking.WorkItem
 		switch syncTechCmdFlagTfsWorkItemType {
 		case "Task":
-			tfsTask, err = tfsAPI.CreateChildTask(ctx, page.Title, page.Description, page.estimate, requirement, tags, "", "", "", "")
+			tfsTask, err = tfsAPI.CreateChildTask(ctx, page.Title, page.Description, page.estimate, requirement, tags, page.assignedTo, "", "", "")
 		case "Requirement":
-			tfsTask, err = tfsAPI.CreateChildRequirement(ctx, "Technical", page.Title, page.Description, page.estimate, page.priority, requirement, tags)
+			tfsTask, err = tfsAPI.CreateChildRequirement(ctx, page.requirementType, page.Title, page.Description, page.estimate, page.priority, requirement, tags, page.areaPath, page.assignedTo)
 		default:
 			return fmt.Errorf("unknown work item type: %s", syncTechCmdFlagTfsWorkItemType)
 		}
@@ -242,6 +468,7 @@ func createTechDebtTasks(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs
 			if err != nil {
 				pterm.Error.Println(fmt.Sprintf("Wiki page NOT UPDATED %s: %s", page.Title, err.Error()))
 			} else {
+				state.setPage(page.PageID, *tfsTask.Id, page.content.Version.Number+1)
 				pterm.Success.Println(fmt.Sprintf("CREATED %s", page.Title))
 			}
 		}
@@ -252,6 +479,34 @@ func createTechDebtTasks(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs
 	return nil
 }
 
+func getTechDebtPageIDs(wikiAPI *wiki.API, parentPageID string, opts ...wiki.DescendantPagesOpt) ([]string, error) {
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Searching Tech Debt pages...")
+	defer func() {
+		_ = spinner.Stop()
+	}()
+
+	pages, err := wikiAPI.GetDescendantPages(parentPageID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(pages, func(page goconfluence.Results, _ int) string {
+		return page.ID
+	}), nil
+}
+
+func linkTechDebtPage(wikiAPI *wiki.API, page *techDebtPage, workItemID int, state *techDebtSyncState) {
+	page.AddTfsTask(workItemID)
+	err := updateTechDebtWikiPage(wikiAPI, page)
+	if err != nil {
+		pterm.Error.Println(fmt.Sprintf("Wiki page NOT UPDATED %s: %s", page.Title, err.Error()))
+		return
+	}
+
+	state.setPage(page.PageID, workItemID, page.content.Version.Number+1)
+	pterm.Success.Println(fmt.Sprintf("LINKED %s to %d", page.Title, workItemID))
+}
+
 func parseTechDebtPages(ctx context.Context, pageIDs []string, api *wiki.API) ([]*techDebtPage, error) {
 	var pages []*techDebtPage
 	wg, _ := errgroup.WithContext(ctx)
@@ -281,7 +536,7 @@ func parseTechDebtPages(ctx context.Context, pageIDs []string, api *wiki.API) ([
 				return err
 			}
 
-			priority, err := strconv.ParseFloat(techDebtPriorityRegexp.FindString(content.Title), 32)
+			priority, err := parseTechDebtTitlePriority(content.Title)
 			if err != nil {
 				priority = float64(syncTechCmdFlagTfsDefaultPriority)
 			}
@@ -306,6 +561,7 @@ func parseTechDebtPages(ctx context.Context, pageIDs []string, api *wiki.API) ([
 				content:  content,
 				estimate: float32(estimation),
 				priority: float32(priority),
+				tags:     slices.Clone(techDebt.Labels),
 			})
 			m.Unlock()
 
@@ -349,7 +605,7 @@ func updateTechDebtWikiPage(api *wiki.API, page *techDebtPage) error {
 	return err
 }
 
-func requestConfirmationTechDebt() (bool, error) {
+func requestConfirmationKey() (bool, error) {
 	pterm.DefaultHeader.
 		WithFullWidth().
 		WithBackgroundStyle(pterm.NewStyle(pterm.BgDefault)).
@@ -413,21 +669,25 @@ func archiveTechCommand(ctx context.Context) error {
 		return err
 	}
 
-	var pageIDs []string
-	searchResult, err := wikiAPI.GetChildPages(strconv.Itoa(wikiParentID))
+	pageIDs, err := getTechDebtPageIDs(wikiAPI, strconv.Itoa(wikiParentID),
+		wiki.DescendantsDepth(archiveTechCmdFlagDepth),
+		wiki.DescendantsLabels(archiveTechCmdFlagLabels...),
+		wiki.DescendantsCQL(archiveTechCmdFlagCQL),
+		wiki.DescendantsExclude(strconv.Itoa(wikiArchiveID)),
+	)
 	if err != nil {
 		return err
 	}
 
-	for _, page := range searchResult.Results {
-		pageIDs = append(pageIDs, page.ID)
-	}
-
 	pages, err := parseTechDebtPages(ctx, pageIDs, wikiAPI)
 	if err != nil {
 		return err
 	}
 
+	pages = lo.Reject(pages, func(page *techDebtPage, _ int) bool {
+		return page.IsEmptyPage
+	})
+
 	if len(pages) == 0 {
 		fmt.Println("nothing to archive")
 		return nil
@@ -445,10 +705,7 @@ func archiveTechCommand(ctx context.Context) error {
 
 	pages = lo.Filter(pages, func(page *techDebtPage, _ int) bool {
 		pageTasks, ok := tasks[page.PageID]
-		return ok && lo.EveryBy(pageTasks, func(wi *workitemtracking.WorkItem) bool {
-			state, ok := (*wi.Fields)["System.State"]
-			return ok && (state == "Closed" || state == "Resolved")
-		})
+		return ok && lo.EveryBy(pageTasks, isTechDebtTaskClosed)
 	})
 
 	if len(pages) == 0 {
@@ -458,7 +715,65 @@ func archiveTechCommand(ctx context.Context) error {
 
 	previewTechDebtTasks(pages)
 
-	ok, err := requestConfirmationTechDebt()
+	ok, err := requestConfirmationKey()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("canceled by user")
+	}
+
+	return moveTechDebtPages(pages, wikiAPI, strconv.Itoa(wikiArchiveID), "Archiving", "ARCHIVED")
+}
+
+func unarchiveTechCommand(ctx context.Context) error {
+	wikiParentID := int(unarchiveTechCmdFlagWikiParentPageID)
+	wikiArchiveID := int(unarchiveTechCmdFlagWikiArchivePageID)
+
+	wikiAPI, err := wiki.NewClient()
+	if err != nil {
+		return err
+	}
+
+	pageIDs, err := getTechDebtPageIDs(wikiAPI, strconv.Itoa(wikiArchiveID), wiki.DescendantsDepth(1))
+	if err != nil {
+		return err
+	}
+
+	pages, err := parseTechDebtPages(ctx, pageIDs, wikiAPI)
+	if err != nil {
+		return err
+	}
+
+	if len(pages) == 0 {
+		fmt.Println("nothing to unarchive")
+		return nil
+	}
+
+	tfsAPI, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := getTechDebtTasks(ctx, pages, tfsAPI)
+	if err != nil {
+		return err
+	}
+
+	pages = lo.Filter(pages, func(page *techDebtPage, _ int) bool {
+		pageTasks, ok := tasks[page.PageID]
+		return ok && !lo.EveryBy(pageTasks, isTechDebtTaskClosed)
+	})
+
+	if len(pages) == 0 {
+		fmt.Println("nothing to unarchive")
+		return nil
+	}
+
+	previewTechDebtTasks(pages)
+
+	ok, err := requestConfirmationKey()
 	if err != nil {
 		return err
 	}
@@ -467,7 +782,12 @@ func archiveTechCommand(ctx context.Context) error {
 		return errors.New("canceled by user")
 	}
 
-	return archiveTechDebtPages(pages, wikiAPI, strconv.Itoa(wikiArchiveID))
+	return moveTechDebtPages(pages, wikiAPI, strconv.Itoa(wikiParentID), "Unarchiving", "UNARCHIVED")
+}
+
+func isTechDebtTaskClosed(wi *workitemtracking.WorkItem) bool {
+	closedStates := viper.GetStringSlice("techDebtClosedStates")
+	return lo.Contains(closedStates, workitem.GetState(wi))
 }
 
 func getTechDebtTasks(ctx context.Context, pages []*techDebtPage, api *tfs.API) (map[string][]*workitemtracking.WorkItem, error) {
@@ -511,19 +831,19 @@ func getTechDebtTasks(ctx context.Context, pages []*techDebtPage, api *tfs.API)
 	return workItems, nil
 }
 
-func archiveTechDebtPages(pages []*techDebtPage, wikiAPI *wiki.API, archivePageID string) error {
+func moveTechDebtPages(pages []*techDebtPage, wikiAPI *wiki.API, targetPageID, action, result string) error {
 	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(pages)).WithRemoveWhenDone().Start()
 	if err != nil {
 		return err
 	}
 
 	for _, page := range pages {
-		progressbar.UpdateTitle(fmt.Sprintf("Archiving %s", cutString(page.Title, 20, true)))
-		err := wikiAPI.MovePage(page.content.Space.Key, page.PageID, archivePageID)
+		progressbar.UpdateTitle(fmt.Sprintf("%s %s", action, cutString(page.Title, 20, true)))
+		err := wikiAPI.MovePage(page.content.Space.Key, page.PageID, targetPageID)
 		if err != nil {
-			pterm.Error.Println(fmt.Sprintf("NOT ARCHIVED %s: %s", page.Title, err.Error()))
+			pterm.Error.Println(fmt.Sprintf("NOT %s %s: %s", result, page.Title, err.Error()))
 		} else {
-			pterm.Success.Println(fmt.Sprintf("ARCHIVED %s", page.Title))
+			pterm.Success.Println(fmt.Sprintf("%s %s", result, page.Title))
 		}
 
 		progressbar.Increment()
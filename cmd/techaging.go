@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+	"tasker/wiki"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	agingTechCmd = &cobra.Command{
+		Use:   "aging",
+		Short: "Tech debt aging report",
+		Long: `Show how long technical debt tasks exist and how long they are untouched.
+Age is counted from the wiki page creation, inactivity from the last change of the page or any of its linked work items.
+Buckets thresholds are configured by techDebtAgeBuckets and techDebtInactivityBuckets (in days).`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := agingTechCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	agingTechCmdFlagWikiParentPageID  uint
+	agingTechCmdFlagDepth             int
+	agingTechCmdFlagLabels            []string
+	agingTechCmdFlagCQL               string
+	agingTechCmdFlagMinInactivityDays uint
+	agingTechCmdFlagOutput            string
+	agingTechCmdFlagPublishPageID     uint
+)
+
+func init() {
+	techCmd.AddCommand(agingTechCmd)
+
+	agingTechCmd.Flags().UintVarP(&agingTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "ID of Wiki parent page with Tech Debt tasks")
+	agingTechCmd.Flags().IntVarP(&agingTechCmdFlagDepth, "depth", "", 1, "Depth of Tech Debt pages search under parent page (0 - unlimited)")
+	agingTechCmd.Flags().StringSliceVarP(&agingTechCmdFlagLabels, "label", "l", nil, "Only Tech Debt pages with any of specified labels")
+	agingTechCmd.Flags().StringVarP(&agingTechCmdFlagCQL, "cql", "", "", "Only Tech Debt pages matching CQL query")
+	agingTechCmd.Flags().UintVarP(&agingTechCmdFlagMinInactivityDays, "inactive", "", 0, "Only Tech Debt tasks untouched at least the specified number of days")
+	agingTechCmd.Flags().StringVarP(&agingTechCmdFlagOutput, "output", "o", "table", "Output format (table, json)")
+	agingTechCmd.Flags().UintVarP(&agingTechCmdFlagPublishPageID, "publish-page", "", 0, "ID of Wiki page to publish the report to")
+
+	cobra.CheckErr(agingTechCmd.MarkFlagRequired("parent-page"))
+}
+
+type techDebtAgingWorkItem struct {
+	ID          int       `json:"id"`
+	State       string    `json:"state"`
+	CreatedDate time.Time `json:"createdDate"`
+	ChangedDate time.Time `json:"changedDate"`
+}
+
+type techDebtAgingItem struct {
+	PageID           string                  `json:"pageId"`
+	Title            string                  `json:"title"`
+	URL              string                  `json:"url"`
+	PageVersion      int                     `json:"pageVersion"`
+	PageCreatedDate  time.Time               `json:"pageCreatedDate"`
+	PageChangedDate  time.Time               `json:"pageChangedDate"`
+	WorkItems        []techDebtAgingWorkItem `json:"workItems"`
+	AgeDays          int                     `json:"ageDays"`
+	InactivityDays   int                     `json:"inactivityDays"`
+	AgeBucket        string                  `json:"ageBucket"`
+	InactivityBucket string                  `json:"inactivityBucket"`
+}
+
+type techDebtAgingReport struct {
+	GeneratedAt       time.Time           `json:"generatedAt"`
+	AgeBuckets        []string            `json:"ageBuckets"`
+	InactivityBuckets []string            `json:"inactivityBuckets"`
+	Items             []techDebtAgingItem `json:"items"`
+}
+
+func agingTechCommand(ctx context.Context) error {
+	err := checkOutputFormat(agingTechCmdFlagOutput, outputFormatTable, outputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	ageThresholds, err := getTechDebtAgingThresholds("techDebtAgeBuckets")
+	if err != nil {
+		return err
+	}
+
+	inactivityThresholds, err := getTechDebtAgingThresholds("techDebtInactivityBuckets")
+	if err != nil {
+		return err
+	}
+
+	wikiAPI, err := wiki.NewClient()
+	if err != nil {
+		return err
+	}
+
+	pageIDs, err := getTechDebtPageIDs(wikiAPI, strconv.Itoa(int(agingTechCmdFlagWikiParentPageID)),
+		wiki.DescendantsDepth(agingTechCmdFlagDepth),
+		wiki.DescendantsLabels(agingTechCmdFlagLabels...),
+		wiki.DescendantsCQL(agingTechCmdFlagCQL),
+	)
+	if err != nil {
+		return err
+	}
+
+	pages, err := parseTechDebtPages(ctx, pageIDs, wikiAPI)
+	if err != nil {
+		return err
+	}
+
+	pages = lo.Reject(pages, func(page *techDebtPage, _ int) bool {
+		return page.IsEmptyPage
+	})
+
+	if len(pages) == 0 {
+		fmt.Println("no tech debt found")
+		return nil
+	}
+
+	tfsAPI, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Collecting history...")
+	items, err := getTechDebtAgingItems(ctx, pages, wikiAPI, tfsAPI)
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := &techDebtAgingReport{
+		GeneratedAt:       now,
+		AgeBuckets:        getAgingBucketNames(ageThresholds),
+		InactivityBuckets: getAgingBucketNames(inactivityThresholds),
+	}
+
+	for _, item := range items {
+		item.AgeDays = daysSince(now, item.PageCreatedDate)
+		item.InactivityDays = daysSince(now, getTechDebtLastActivity(item))
+		item.AgeBucket = getAgingBucket(item.AgeDays, ageThresholds)
+		item.InactivityBucket = getAgingBucket(item.InactivityDays, inactivityThresholds)
+
+		if item.InactivityDays >= int(agingTechCmdFlagMinInactivityDays) {
+			report.Items = append(report.Items, *item)
+		}
+	}
+
+	slices.SortStableFunc(report.Items, func(a, b techDebtAgingItem) int {
+		return cmp.Or(cmp.Compare(b.InactivityDays, a.InactivityDays), cmp.Compare(b.AgeDays, a.AgeDays))
+	})
+
+	if agingTechCmdFlagOutput == outputFormatJSON {
+		err = printJSON(report)
+		if err != nil {
+			return err
+		}
+	} else {
+		renderTechDebtAgingReport(report)
+	}
+
+	if agingTechCmdFlagPublishPageID != 0 {
+		err = wiki.UploadContent(wikiAPI, strconv.Itoa(int(agingTechCmdFlagPublishPageID)), getTechDebtAgingMarkdown(report), "md")
+		if err != nil {
+			return err
+		}
+		pterm.Success.Println(fmt.Sprintf("PUBLISHED to page %d", agingTechCmdFlagPublishPageID))
+	}
+
+	return nil
+}
+
+func getTechDebtAgingItems(ctx context.Context, pages []*techDebtPage, wikiAPI *wiki.API, tfsAPI *tfs.API) ([]*techDebtAgingItem, error) {
+	tasks, err := getTechDebtTasks(ctx, pages, tfsAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*techDebtAgingItem
+	wg, _ := errgroup.WithContext(ctx)
+	var m sync.Mutex
+	guard := make(chan struct{}, 10)
+	for _, page := range pages {
+		wg.Go(func() error {
+			guard <- struct{}{}
+			defer func() {
+				<-guard
+			}()
+
+			history, err := wikiAPI.GetHistory(page.PageID)
+			if err != nil {
+				return err
+			}
+
+			item := &techDebtAgingItem{
+				PageID:          page.PageID,
+				Title:           page.content.Title,
+				PageCreatedDate: parseWikiTime(history.CreatedDate),
+				PageChangedDate: parseWikiTime(history.LastUpdated.When),
+				WorkItems: lo.Map(tasks[page.PageID], func(wi *workitemtracking.WorkItem, _ int) techDebtAgingWorkItem {
+					return techDebtAgingWorkItem{
+						ID:          *wi.Id,
+						State:       workitem.GetState(wi),
+						CreatedDate: workitem.GetCreatedDate(wi),
+						ChangedDate: workitem.GetChangedDate(wi),
+					}
+				}),
+			}
+
+			if page.content.Version != nil {
+				item.PageVersion = page.content.Version.Number
+			}
+
+			if page.content.Links != nil {
+				item.URL = page.content.Links.Base + page.content.Links.WebUI
+			}
+
+			m.Lock()
+			items = append(items, item)
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	err = wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func getTechDebtAgingThresholds(key string) ([]int, error) {
+	thresholds := viper.GetIntSlice(key)
+	if len(thresholds) == 0 {
+		return nil, fmt.Errorf("%s is empty", key)
+	}
+
+	if lo.SomeBy(thresholds, func(days int) bool { return days <= 0 }) {
+		return nil, errors.New(key + " must contain positive numbers of days")
+	}
+
+	thresholds = lo.Uniq(thresholds)
+	slices.Sort(thresholds)
+	return thresholds, nil
+}
+
+// getTechDebtLastActivity returns the latest change of the page or any of its work items.
+func getTechDebtLastActivity(item *techDebtAgingItem) time.Time {
+	last := item.PageChangedDate
+	for _, wi := range item.WorkItems {
+		if wi.ChangedDate.After(last) {
+			last = wi.ChangedDate
+		}
+	}
+	return last
+}
+
+func getAgingBucketNames(thresholds []int) []string {
+	names := []string{fmt.Sprintf("<%dd", thresholds[0])}
+	for i := 1; i < len(thresholds); i++ {
+		names = append(names, fmt.Sprintf("%d-%dd", thresholds[i-1], thresholds[i]))
+	}
+	return append(names, fmt.Sprintf(">=%dd", thresholds[len(thresholds)-1]))
+}
+
+func getAgingBucket(days int, thresholds []int) string {
+	names := getAgingBucketNames(thresholds)
+	index, _ := slices.BinarySearch(thresholds, days+1)
+	return names[index]
+}
+
+func daysSince(now, t time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+	return int(now.Sub(t).Hours() / 24)
+}
+
+func parseWikiTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func getTechDebtAgingMatrix(report *techDebtAgingReport) [][]string {
+	matrix := [][]string{append([]string{"Age \\ Inactivity"}, report.InactivityBuckets...)}
+	for _, ageBucket := range report.AgeBuckets {
+		row := []string{ageBucket}
+		for _, inactivityBucket := range report.InactivityBuckets {
+			count := lo.CountBy(report.Items, func(item techDebtAgingItem) bool {
+				return item.AgeBucket == ageBucket && item.InactivityBucket == inactivityBucket
+			})
+			row = append(row, strconv.Itoa(count))
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix
+}
+
+func getTechDebtAgingWorkItemsString(item techDebtAgingItem) string {
+	return strings.Join(lo.Map(item.WorkItems, func(wi techDebtAgingWorkItem, _ int) string {
+		return fmt.Sprintf("%d (%s)", wi.ID, wi.State)
+	}), ", ")
+}
+
+func renderTechDebtAgingReport(report *techDebtAgingReport) {
+	tableData := [][]string{{"#", "Title", "Age", "Inactive", "Work items"}}
+	for i, item := range report.Items {
+		tableData = append(tableData, []string{
+			strconv.Itoa(i + 1),
+			cutString(item.Title, 60, false),
+			fmt.Sprintf("%dd", item.AgeDays),
+			fmt.Sprintf("%dd", item.InactivityDays),
+			getTechDebtAgingWorkItemsString(item),
+		})
+	}
+
+	_ = pterm.DefaultTable.
+		WithHasHeader().
+		WithData(tableData).
+		Render()
+
+	fmt.Println()
+
+	_ = pterm.DefaultTable.
+		WithHasHeader().
+		WithData(getTechDebtAgingMatrix(report)).
+		Render()
+}
+
+func getTechDebtAgingMarkdown(report *techDebtAgingReport) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Generated at %s\n\n", report.GeneratedAt.Format(time.DateTime)))
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString(getMarkdownTable(getTechDebtAgingMatrix(report)))
+
+	sb.WriteString("\n## Tasks\n\n")
+	rows := [][]string{{"Title", "Age", "Inactive", "Work items"}}
+	for _, item := range report.Items {
+		title := item.Title
+		if item.URL != "" {
+			title = fmt.Sprintf("[%s](%s)", title, item.URL)
+		}
+		rows = append(rows, []string{
+			title,
+			fmt.Sprintf("%dd", item.AgeDays),
+			fmt.Sprintf("%dd", item.InactivityDays),
+			getTechDebtAgingWorkItemsString(item),
+		})
+	}
+	sb.WriteString(getMarkdownTable(rows))
+
+	return sb.String()
+}
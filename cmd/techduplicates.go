@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// titleTokenStemLength is the length of crude stemming of title words,
+	// it allows to match different word forms ("синхронизация", "синхронизации").
+	titleTokenStemLength = 6
+	titleWeight          = 0.8
+	labelsWeight         = 0.2
+)
+
+var titleBracketsRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+type techDebtDuplicate struct {
+	workItemID int
+	title      string
+	score      float64
+}
+
+func (d *techDebtDuplicate) String() string {
+	if d.workItemID != 0 {
+		return fmt.Sprintf("duplicate of #%d %.0f%%", d.workItemID, d.score*100)
+	}
+	return fmt.Sprintf("duplicate of '%s' %.0f%%", cutString(d.title, 30, false), d.score*100)
+}
+
+// findTechDebtDuplicates marks pages which are likely duplicates of other new pages
+// or of existing work items under the requirement.
+func findTechDebtDuplicates(ctx context.Context, pages []*techDebtPage, tfsAPI *tfs.API, requirementID int, threshold float64) error {
+	existing, err := tfsAPI.WiClient.GetChildren(ctx, requirementID, []string{"System.Id", "System.Title", "System.Tags"})
+	if err != nil {
+		return err
+	}
+
+	// pages are parsed concurrently, the earlier created page is compared first to be treated as the original
+	pages = slices.SortedFunc(slices.Values(pages), func(a, b *techDebtPage) int {
+		return cmp.Or(cmp.Compare(len(a.PageID), len(b.PageID)), strings.Compare(a.PageID, b.PageID))
+	})
+
+	for i, page := range pages {
+		pageTokens := getTitleTokens(page.Title)
+
+		var best *techDebtDuplicate
+		for j := range existing {
+			wi := &existing[j]
+			score := getDuplicateScore(pageTokens, getTitleTokens(workitem.GetTitle(wi)), page.tags, workitem.GetTags(wi))
+			if score >= threshold && (best == nil || score > best.score) {
+				best = &techDebtDuplicate{workItemID: *wi.Id, title: workitem.GetTitle(wi), score: score}
+			}
+		}
+
+		for _, other := range pages[:i] {
+			score := getDuplicateScore(pageTokens, getTitleTokens(other.Title), page.tags, other.tags)
+			if score >= threshold && (best == nil || score > best.score) {
+				best = &techDebtDuplicate{title: other.Title, score: score}
+			}
+		}
+
+		page.duplicate = best
+	}
+
+	return nil
+}
+
+func getDuplicateScore(titleTokens, otherTitleTokens, labels, otherLabels []string) float64 {
+	titleScore := jaccardIndex(titleTokens, otherTitleTokens)
+	if len(labels) == 0 && len(otherLabels) == 0 {
+		return titleScore
+	}
+
+	normalizeLabels := func(labels []string) []string {
+		return lo.Map(labels, func(label string, _ int) string { return strings.ToLower(strings.TrimSpace(label)) })
+	}
+
+	return titleWeight*titleScore + labelsWeight*jaccardIndex(normalizeLabels(labels), normalizeLabels(otherLabels))
+}
+
+// getTitleTokens normalizes the title: removes work item prefixes like "[SMP] [tech]", priority and estimate,
+// lowercases it and splits into stemmed words.
+func getTitleTokens(title string) []string {
+	title = titleBracketsRegexp.ReplaceAllString(title, " ")
+	title = techDebtPriorityRegexp.ReplaceAllString(title, " ")
+	title = strings.ToLower(title)
+
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := lo.FilterMap(words, func(word string, _ int) (string, bool) {
+		runes := []rune(word)
+		if len(runes) < 2 {
+			return "", false
+		}
+		if len(runes) > titleTokenStemLength {
+			runes = runes[:titleTokenStemLength]
+		}
+		return string(runes), true
+	})
+
+	return lo.Uniq(tokens)
+}
+
+func jaccardIndex(a, b []string) float64 {
+	union := lo.Union(a, b)
+	if len(union) == 0 {
+		return 0
+	}
+	return float64(len(lo.Intersect(a, b))) / float64(len(union))
+}
+
+func linkTechDebtDuplicates(pages []*techDebtPage) {
+	for _, page := range pages {
+		if page.duplicate != nil && page.duplicate.workItemID != 0 {
+			page.workItemID = page.duplicate.workItemID
+		}
+	}
+}
+
+func isLinkedTechDebtDuplicate(page *techDebtPage) bool {
+	return page.duplicate != nil && page.duplicate.workItemID != 0 && page.workItemID == page.duplicate.workItemID
+}
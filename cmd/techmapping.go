@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+)
+
+const defaultTechDebtRequirementType = "Technical"
+
+// techDebtLabelRule maps wiki page labels matching the Label pattern to TFS work item fields.
+type techDebtLabelRule struct {
+	Label           string   `mapstructure:"label"`
+	AreaPath        string   `mapstructure:"areaPath"`
+	Tags            []string `mapstructure:"tags"`
+	PriorityBoost   float32  `mapstructure:"priorityBoost"`
+	RequirementType string   `mapstructure:"requirementType"`
+	AssignedTo      string   `mapstructure:"assignedTo"`
+	labelRegexp     *regexp.Regexp
+}
+
+func loadTechDebtLabelMapping() ([]*techDebtLabelRule, error) {
+	var rules []*techDebtLabelRule
+	err := viper.UnmarshalKey("techDebtLabelMapping", &rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid techDebtLabelMapping: %w", err)
+	}
+
+	for _, rule := range rules {
+		rule.labelRegexp, err = regexp.Compile("^(" + rule.Label + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid techDebtLabelMapping label pattern '%s': %w", rule.Label, err)
+		}
+	}
+
+	return rules, nil
+}
+
+func (r *techDebtLabelRule) matches(labels []string) bool {
+	return lo.SomeBy(labels, func(label string) bool {
+		return r.labelRegexp.MatchString(label)
+	})
+}
+
+// applyTechDebtLabelMapping fills work item fields of the pages by the rules matching page labels.
+// Area path, requirement type and assignee are taken from the first matching rule which defines them,
+// tags are accumulated and priority boosts are summed up (the lower priority value the higher priority).
+func applyTechDebtLabelMapping(pages []*techDebtPage, rules []*techDebtLabelRule) {
+	for _, page := range pages {
+		var boost float32
+		for _, rule := range rules {
+			if !rule.matches(page.Labels) {
+				continue
+			}
+
+			if page.areaPath == "" {
+				page.areaPath = rule.AreaPath
+			}
+			if page.requirementType == "" {
+				page.requirementType = rule.RequirementType
+			}
+			if page.assignedTo == "" {
+				page.assignedTo = rule.AssignedTo
+			}
+
+			page.tags = lo.Uniq(append(page.tags, rule.Tags...))
+			boost += rule.PriorityBoost
+		}
+
+		if page.requirementType == "" {
+			page.requirementType = defaultTechDebtRequirementType
+		}
+
+		page.priority = max(page.priority-boost, 1)
+	}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+	"tasker/wiki"
+
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	goconfluence "github.com/virtomize/confluence-go-api"
+)
+
+const defaultTechDebtPageTemplate = `{{.Body}}`
+
+var (
+	newTechCmd = &cobra.Command{
+		Use:   "new <Title>",
+		Short: "Create tech debt task",
+		Long: `Create technical debt wiki page and TFS requirement linked to it.
+If any step fails, already created page and requirement are deleted.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := newTechCommand(cmd.Context(), args[0])
+			cobra.CheckErr(err)
+		},
+	}
+
+	newTechCmdFlagPriority          uint
+	newTechCmdFlagEstimate          uint
+	newTechCmdFlagLabels            []string
+	newTechCmdFlagBodyFile          string
+	newTechCmdFlagWikiParentPageID  uint
+	newTechCmdFlagTfsRequirementID  uint
+	newTechCmdFlagTfsWorkItemPrefix string
+)
+
+func init() {
+	techCmd.AddCommand(newTechCmd)
+
+	newTechCmd.Flags().UintVarP(&newTechCmdFlagPriority, "priority", "", 1, "The tech debt priority")
+	newTechCmd.Flags().UintVarP(&newTechCmdFlagEstimate, "estimate", "e", 16, "The tech debt estimate")
+	newTechCmd.Flags().StringSliceVarP(&newTechCmdFlagLabels, "label", "l", nil, "Labels of the wiki page. Can be separated by comma or specified multiple times.")
+	newTechCmd.Flags().StringVarP(&newTechCmdFlagBodyFile, "body-file", "f", "", "Path to file with page body (storage format or markdown for *.md files)")
+	newTechCmd.Flags().UintVarP(&newTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "The ID of Wiki parent page with Tech Debt tasks (techDebtWikiParentPage by default)")
+	newTechCmd.Flags().UintVarP(&newTechCmdFlagTfsRequirementID, "requirement", "r", 0, "The ID of Parent TFS requirement/feature work item (techDebtTfsRequirement by default)")
+	newTechCmd.Flags().StringVarP(&newTechCmdFlagTfsWorkItemPrefix, "prefix", "", "[SMP] [tech]", "The prefix of work item")
+
+	cobra.CheckErr(newTechCmd.MarkFlagFilename("body-file"))
+}
+
+type techDebtPageTemplateData struct {
+	Title    string
+	Priority uint
+	Estimate uint
+	Labels   []string
+	Body     string
+}
+
+func newTechCommand(ctx context.Context, title string) error {
+	parentPageID := newTechCmdFlagWikiParentPageID
+	if parentPageID == 0 {
+		parentPageID = viper.GetUint("techDebtWikiParentPage")
+	}
+	if parentPageID == 0 {
+		return errors.New("tech debt parent page is not specified")
+	}
+
+	requirementID := newTechCmdFlagTfsRequirementID
+	if requirementID == 0 {
+		requirementID = viper.GetUint("techDebtTfsRequirement")
+	}
+	if requirementID == 0 {
+		return errors.New("tech debt parent requirement is not specified")
+	}
+
+	body, err := getNewTechDebtPageBody(title)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start("Creating wiki page...")
+	defer func() {
+		_ = spinner.Stop()
+	}()
+
+	wikiAPI, err := wiki.NewClient()
+	if err != nil {
+		return err
+	}
+
+	tfsAPI, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	requirement, err := tfsAPI.WiClient.Get(ctx, int(requirementID))
+	if err != nil {
+		return err
+	}
+
+	parentPage, err := wikiAPI.GetPageByID(strconv.Itoa(int(parentPageID)))
+	if err != nil {
+		return err
+	}
+
+	created, err := wikiAPI.CreateContent(&goconfluence.Content{
+		Type:  "page",
+		Title: fmt.Sprintf("%d. %s [%d]", newTechCmdFlagPriority, title, newTechCmdFlagEstimate),
+		Ancestors: []goconfluence.Ancestor{
+			{ID: parentPage.ID},
+		},
+		Space: &goconfluence.Space{
+			Key: parentPage.Space.Key,
+		},
+		Body: goconfluence.Body{
+			Storage: goconfluence.Storage{
+				Value:          body,
+				Representation: "storage",
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	rollbackPage := func(cause error) error {
+		_, err := wikiAPI.DelContent(created.ID)
+		if err != nil {
+			return fmt.Errorf("%w; rollback failed, delete wiki page %s manually: %v", cause, created.ID, err)
+		}
+		return fmt.Errorf("%w; wiki page deleted", cause)
+	}
+
+	if len(newTechCmdFlagLabels) > 0 {
+		labels := lo.Map(newTechCmdFlagLabels, func(label string, _ int) goconfluence.Label {
+			return goconfluence.Label{Prefix: "global", Name: label}
+		})
+		_, err = wikiAPI.AddLabels(created.ID, &labels)
+		if err != nil {
+			return rollbackPage(err)
+		}
+	}
+
+	pages, err := parseTechDebtPages(ctx, []string{created.ID}, wikiAPI)
+	if err != nil {
+		return rollbackPage(err)
+	}
+	page := pages[0]
+
+	labelMapping, err := loadTechDebtLabelMapping()
+	if err != nil {
+		return rollbackPage(err)
+	}
+
+	page.Title = getTechDebtWorkItemTitle(page, newTechCmdFlagTfsWorkItemPrefix)
+	applyTechDebtLabelMapping(pages, labelMapping)
+
+	spinner.UpdateText("Creating TFS requirement...")
+	tfsTask, err := tfsAPI.CreateChildRequirement(ctx, page.requirementType, page.Title, page.Description, page.estimate, page.priority, requirement, page.tags, page.areaPath, page.assignedTo)
+	if err != nil {
+		return rollbackPage(err)
+	}
+
+	spinner.UpdateText("Updating wiki page...")
+	page.AddTfsTask(*tfsTask.Id)
+	err = updateTechDebtWikiPage(wikiAPI, page)
+	if err != nil {
+		deleteErr := tfsAPI.WiClient.Destroy(ctx, *tfsTask.Id)
+		if deleteErr != nil {
+			err = fmt.Errorf("%w; rollback failed, delete work item %d manually: %v", err, *tfsTask.Id, deleteErr)
+		}
+		return rollbackPage(err)
+	}
+
+	state, err := loadTechDebtSyncState()
+	if err == nil {
+		state.setPage(page.PageID, *tfsTask.Id, page.content.Version.Number+1)
+		err = state.save()
+	}
+	if err != nil {
+		pterm.Warning.Println(fmt.Sprintf("tech debt sync state not saved: %s", err.Error()))
+	}
+
+	links := page.content.Links
+	spinner.Success(fmt.Sprintf("CREATED %s\n%s%s\n%s", page.Title, links.Base, links.WebUI, workitem.GetURL(tfsTask)))
+
+	return nil
+}
+
+func getNewTechDebtPageBody(title string) (string, error) {
+	var content string
+	if newTechCmdFlagBodyFile != "" {
+		data, err := os.ReadFile(newTechCmdFlagBodyFile)
+		if err != nil {
+			return "", err
+		}
+
+		content = string(data)
+		if wiki.IsMarkdownContentType(strings.TrimPrefix(filepath.Ext(newTechCmdFlagBodyFile), ".")) {
+			content = wiki.MarkdownMacro(content)
+		}
+	}
+
+	templateString := defaultTechDebtPageTemplate
+	templatePath := viper.GetString("techDebtPageTemplatePath")
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", err
+		}
+		templateString = string(data)
+	}
+
+	t, err := template.New("tech debt page template").Parse(templateString)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	err = t.Execute(&result, techDebtPageTemplateData{
+		Title:    title,
+		Priority: newTechCmdFlagPriority,
+		Estimate: newTechCmdFlagEstimate,
+		Labels:   newTechCmdFlagLabels,
+		Body:     content,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
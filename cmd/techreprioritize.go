@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/tasksui"
+	"tasker/tfs"
+	"tasker/wiki"
+
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	goconfluence "github.com/virtomize/confluence-go-api"
+)
+
+var (
+	reprioritizeTechCmd = &cobra.Command{
+		Use:     "reprioritize",
+		Aliases: []string{"prio"},
+		Short:   "Reorder tech debt tasks",
+		Long: `Change the order of technical debt tasks.
+Page titles priority prefixes and Priority of linked TFS work items are renumbered according to the new order.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := reprioritizeTechCommand(cmd.Context())
+			cobra.CheckErr(err)
+		},
+	}
+
+	reprioritizeTechCmdFlagWikiParentPageID uint
+	reprioritizeTechCmdFlagDepth            int
+	reprioritizeTechCmdFlagLabels           []string
+	reprioritizeTechCmdFlagCQL              string
+)
+
+func init() {
+	techCmd.AddCommand(reprioritizeTechCmd)
+
+	reprioritizeTechCmd.Flags().UintVarP(&reprioritizeTechCmdFlagWikiParentPageID, "parent-page", "p", 0, "ID of Wiki parent page with Tech Debt tasks")
+	reprioritizeTechCmd.Flags().IntVarP(&reprioritizeTechCmdFlagDepth, "depth", "", 1, "Depth of Tech Debt pages search under parent page (0 - unlimited)")
+	reprioritizeTechCmd.Flags().StringSliceVarP(&reprioritizeTechCmdFlagLabels, "label", "l", nil, "Only Tech Debt pages with any of specified labels")
+	reprioritizeTechCmd.Flags().StringVarP(&reprioritizeTechCmdFlagCQL, "cql", "", "", "Only Tech Debt pages matching CQL query")
+
+	cobra.CheckErr(reprioritizeTechCmd.MarkFlagRequired("parent-page"))
+}
+
+func reprioritizeTechCommand(ctx context.Context) error {
+	wikiAPI, err := wiki.NewClient()
+	if err != nil {
+		return err
+	}
+
+	pageIDs, err := getTechDebtPageIDs(wikiAPI, strconv.Itoa(int(reprioritizeTechCmdFlagWikiParentPageID)),
+		wiki.DescendantsDepth(reprioritizeTechCmdFlagDepth),
+		wiki.DescendantsLabels(reprioritizeTechCmdFlagLabels...),
+		wiki.DescendantsCQL(reprioritizeTechCmdFlagCQL),
+	)
+	if err != nil {
+		return err
+	}
+
+	pages, err := parseTechDebtPages(ctx, pageIDs, wikiAPI)
+	if err != nil {
+		return err
+	}
+
+	pages = lo.Reject(pages, func(page *techDebtPage, _ int) bool {
+		return page.IsEmptyPage
+	})
+
+	if len(pages) == 0 {
+		fmt.Println("nothing to reprioritize")
+		return nil
+	}
+
+	slices.SortStableFunc(pages, func(a, b *techDebtPage) int {
+		return cmp.Or(cmp.Compare(a.priority, b.priority), strings.Compare(a.Title, b.Title))
+	})
+
+	ok, err := tasksui.ReorderTasks(&techDebtPageTable{pages: pages})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	tfsAPI, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, err := loadTechDebtSyncState()
+	if err != nil {
+		return err
+	}
+
+	// the state keeps titles of the updated pages even if some pages failed
+	renumberErr := renumberTechDebtPages(ctx, pages, wikiAPI, tfsAPI, state)
+	err = state.save()
+	if err != nil {
+		return err
+	}
+
+	return renumberErr
+}
+
+func renumberTechDebtPages(ctx context.Context, pages []*techDebtPage, wikiAPI *wiki.API, tfsAPI *tfs.API, state *techDebtSyncState) error {
+	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(pages)).WithRemoveWhenDone().Start()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for i, page := range pages {
+		priority := i + 1
+		title := getTechDebtTitleWithPriority(page.content.Title, priority)
+
+		progressbar.UpdateTitle(fmt.Sprintf("Updating %s", cutString(title, 20, true)))
+
+		if title != page.content.Title {
+			err := updateTechDebtWikiPageTitle(wikiAPI, page, title, state)
+			if err != nil {
+				pterm.Error.Println(fmt.Sprintf("Wiki page NOT UPDATED %s: %s", title, err.Error()))
+				failed++
+				progressbar.Increment()
+				continue
+			}
+		}
+
+		var tasksFailed bool
+		for _, task := range page.TfsTasks {
+			err := tfsAPI.WiClient.UpdateField(ctx, task.ItemID, "Microsoft.VSTS.Common.Priority", strconv.Itoa(priority))
+			if err != nil {
+				tasksFailed = true
+				pterm.Error.Println(fmt.Sprintf("TFS Task %d NOT UPDATED: %s", task.ItemID, err.Error()))
+			}
+		}
+
+		if tasksFailed {
+			failed++
+		} else {
+			pterm.Success.Println(fmt.Sprintf("UPDATED %s", title))
+		}
+		progressbar.Increment()
+	}
+	_, _ = progressbar.Stop()
+
+	if failed > 0 {
+		return fmt.Errorf("%d pages not updated completely", failed)
+	}
+	return nil
+}
+
+func getTechDebtTitleWithPriority(title string, priority int) string {
+	prefix := fmt.Sprintf("%d. ", priority)
+	if techDebtPriorityRegexp.MatchString(title) {
+		return techDebtPriorityRegexp.ReplaceAllLiteralString(title, prefix)
+	}
+	return prefix + title
+}
+
+func updateTechDebtWikiPageTitle(api *wiki.API, page *techDebtPage, title string, state *techDebtSyncState) error {
+	content := page.content
+	_, err := api.UpdateContent(&goconfluence.Content{
+		ID:    content.ID,
+		Type:  content.Type,
+		Title: title,
+		Space: &goconfluence.Space{
+			Key: content.Space.Key,
+		},
+		Body: goconfluence.Body{
+			Storage: goconfluence.Storage{
+				Value:          content.Body.Storage.Value,
+				Representation: "storage",
+			},
+		},
+		Version: &goconfluence.Version{
+			Number: content.Version.Number + 1,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// keep the page in sync if only its title prefix was changed
+	if pageState, ok := state.Pages[page.PageID]; ok && pageState.Version == content.Version.Number {
+		state.setPage(page.PageID, pageState.WorkItemID, content.Version.Number+1)
+	}
+
+	return nil
+}
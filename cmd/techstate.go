@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+const techDebtStateFileName = ".tasker.tech-state.json"
+
+type techDebtSyncState struct {
+	Pages map[string]techDebtPageState `json:"pages"`
+}
+
+type techDebtPageState struct {
+	WorkItemID int `json:"workItemId"`
+	Version    int `json:"version"`
+}
+
+func getTechDebtStatePath() (string, error) {
+	statePath := viper.GetString("techDebtStatePath")
+	if statePath != "" {
+		return statePath, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, techDebtStateFileName), nil
+}
+
+func loadTechDebtSyncState() (*techDebtSyncState, error) {
+	state := &techDebtSyncState{
+		Pages: make(map[string]techDebtPageState),
+	}
+
+	statePath, err := getTechDebtStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Pages == nil {
+		state.Pages = make(map[string]techDebtPageState)
+	}
+
+	return state, nil
+}
+
+func (s *techDebtSyncState) save() error {
+	statePath, err := getTechDebtStatePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statePath, data, 0o600)
+}
+
+func (s *techDebtSyncState) setPage(pageID string, workItemID, version int) {
+	s.Pages[pageID] = techDebtPageState{
+		WorkItemID: workItemID,
+		Version:    version,
+	}
+}
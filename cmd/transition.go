@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	transitionWorkItemsCmd = &cobra.Command{
+		Use:   "transition <Work Item ID, ...>",
+		Short: "Change state of work items",
+		Long: `Move work items into the state following the workflow of their types.
+Intermediate states are passed when there is no direct transition, fields are filled by workItemTransitionRules.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			workItemIDs, err := parseWorkItemIDs(args)
+			cobra.CheckErr(err)
+
+			err = transitionWorkItemsCommand(cmd.Context(), workItemIDs, transitionWorkItemsCmdFlagTo, workItemTransitionOptions{
+				reason:  transitionWorkItemsCmdFlagReason,
+				comment: transitionWorkItemsCmdFlagComment,
+				dryRun:  transitionWorkItemsCmdFlagDryRun,
+			})
+			cobra.CheckErr(err)
+		},
+	}
+
+	transitionWorkItemsCmdFlagTo      string
+	transitionWorkItemsCmdFlagReason  string
+	transitionWorkItemsCmdFlagComment string
+	transitionWorkItemsCmdFlagDryRun  bool
+)
+
+func init() {
+	rootCmd.AddCommand(transitionWorkItemsCmd)
+
+	transitionWorkItemsCmd.Flags().StringVarP(&transitionWorkItemsCmdFlagTo, "to", "t", "", "Target state")
+	transitionWorkItemsCmd.Flags().StringVarP(&transitionWorkItemsCmdFlagReason, "reason", "r", "", "Reason of the transition into target state")
+	transitionWorkItemsCmd.Flags().StringVarP(&transitionWorkItemsCmdFlagComment, "comment", "c", "", "Comment (Markdown, @mentions) added to the discussion with the transition into target state")
+	transitionWorkItemsCmd.Flags().BoolVarP(&transitionWorkItemsCmdFlagDryRun, "dry-run", "", false, "Show transitions without changing work items")
+
+	cobra.CheckErr(transitionWorkItemsCmd.MarkFlagRequired("to"))
+}
+
+// workItemTransitionRule fills fields of work items of the Type (any type if empty) moved into the State.
+// Field values are templates with functions field (value of the work item field by name) and add.
+type workItemTransitionRule struct {
+	Type   string                    `mapstructure:"type"`
+	State  string                    `mapstructure:"state"`
+	Fields []workItemTransitionField `mapstructure:"fields"`
+}
+
+type workItemTransitionField struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+}
+
+var defaultWorkItemTransitionRules = []map[string]any{
+	{
+		"type":  "Task",
+		"state": "Closed",
+		"fields": []map[string]any{
+			{"name": "CompletedWork", "value": `{{ add (field "CompletedWork") (field "RemainingWork") }}`},
+			{"name": "RemainingWork", "value": "0"},
+		},
+	},
+}
+
+// workItemTransitionOptions are applied with the transition into the target state.
+type workItemTransitionOptions struct {
+	reason  string
+	comment string
+	dryRun  bool
+	rules   []workItemTransitionRule
+}
+
+type workItemTransitionResult struct {
+	workItemID int
+	title      string
+	states     []string
+	err        error
+}
+
+func transitionWorkItemsCommand(ctx context.Context, workItemIDs []int, state string, options workItemTransitionOptions) error {
+	err := viper.UnmarshalKey("workItemTransitionRules", &options.rules)
+	if err != nil {
+		return fmt.Errorf("invalid workItemTransitionRules: %w", err)
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := transitionWorkItems(ctx, a, workItemIDs, state, options)
+
+	var failed int
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			pterm.Error.Println(fmt.Sprintf("NOT TRANSITIONED %d %s: %s", result.workItemID, result.title, result.err.Error()))
+		case len(result.states) == 1:
+			pterm.Info.Println(fmt.Sprintf("ALREADY %s %d %s", state, result.workItemID, result.title))
+		case options.dryRun:
+			pterm.Info.Println(fmt.Sprintf("WILL TRANSITION %d %s: %s", result.workItemID, result.title, strings.Join(result.states, " → ")))
+		default:
+			pterm.Success.Println(fmt.Sprintf("TRANSITIONED %d %s: %s", result.workItemID, result.title, strings.Join(result.states, " → ")))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d work items not transitioned", failed)
+	}
+	return nil
+}
+
+func transitionWorkItems(ctx context.Context, api *tfs.API, workItemIDs []int, state string, options workItemTransitionOptions) []*workItemTransitionResult {
+	progressbar, _ := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(workItemIDs)).WithRemoveWhenDone().Start()
+
+	results := lo.Map(workItemIDs, func(id int, _ int) *workItemTransitionResult {
+		return &workItemTransitionResult{workItemID: id}
+	})
+
+	wg, _ := errgroup.WithContext(ctx)
+	var m sync.Mutex
+	guard := make(chan struct{}, 10)
+	for _, result := range results {
+		wg.Go(func() error {
+			guard <- struct{}{}
+			defer func() {
+				<-guard
+			}()
+
+			result.err = transitionWorkItem(ctx, api, result, state, options)
+
+			m.Lock()
+			if progressbar != nil {
+				progressbar.Increment()
+			}
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	_ = wg.Wait()
+	if progressbar != nil {
+		_, _ = progressbar.Stop()
+	}
+
+	return results
+}
+
+func transitionWorkItem(ctx context.Context, api *tfs.API, result *workItemTransitionResult, state string, options workItemTransitionOptions) error {
+	wi, err := api.WiClient.Get(ctx, result.workItemID)
+	if err != nil {
+		return err
+	}
+
+	result.title = workitem.GetTitle(wi)
+	currentState := workitem.GetState(wi)
+	result.states = []string{currentState}
+
+	transitions, err := api.WiClient.GetTypeTransitions(ctx, workitem.GetType(wi))
+	if err != nil {
+		return err
+	}
+
+	path := workitem.FindTransitionPath(transitions, currentState, state)
+	if path == nil {
+		return fmt.Errorf("no transitions from %s to %s for %s", currentState, state, workitem.GetType(wi))
+	}
+
+	result.states = append(result.states, path...)
+	if options.dryRun {
+		return nil
+	}
+
+	for i, step := range path {
+		fields, err := getWorkItemTransitionFields(wi, step, options.rules)
+		if err != nil {
+			return err
+		}
+
+		if i == len(path)-1 && options.reason != "" {
+			fields["System.Reason"] = options.reason
+		}
+
+		wi, err = api.WiClient.Transition(workitem.WithPrevious(ctx, wi), result.workItemID, step, fields)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.comment != "" && len(path) > 0 {
+		err = addWorkItemComment(ctx, api, result.workItemID, options.comment)
+		if err != nil {
+			return fmt.Errorf("transitioned, but comment not added: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func getWorkItemTransitionFields(wi *workitemtracking.WorkItem, state string, rules []workItemTransitionRule) (map[string]any, error) {
+	funcs := template.FuncMap{
+		"field": func(name string) any {
+			return getWorkItemFieldValue(wi, getWorkItemFieldReferenceName(name))
+		},
+		"add": func(values ...any) float64 {
+			return lo.SumBy(values, toFloat)
+		},
+	}
+
+	fields := make(map[string]any)
+	for _, rule := range rules {
+		if rule.State != state || (rule.Type != "" && rule.Type != workitem.GetType(wi)) {
+			continue
+		}
+
+		for _, field := range rule.Fields {
+			t, err := template.New(field.Name).Funcs(funcs).Parse(field.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transition rule for field %s: %w", field.Name, err)
+			}
+
+			var value bytes.Buffer
+			err = t.Execute(&value, nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transition rule for field %s: %w", field.Name, err)
+			}
+
+			fields[getWorkItemFieldReferenceName(field.Name)] = value.String()
+		}
+	}
+
+	return fields, nil
+}
+
+func toFloat(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	default:
+		return 0
+	}
+}
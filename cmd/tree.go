@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"tasker/browser"
+	"tasker/tasksui"
+	"tasker/tfs"
+	"tasker/tfs/workitem"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var treeWorkItemCmd = &cobra.Command{
+	Use:   "tree <Work Item ID>",
+	Short: "Browse hierarchy of work item",
+	Long: `Browse children of the work item as a tree with remaining work rolled up from subtrees.
+Children are loaded on expanding, work items can be edited, moved into another state and under another parent, opened in browser.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workItemID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		err = treeWorkItemCommand(cmd.Context(), workItemID)
+		cobra.CheckErr(err)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(treeWorkItemCmd)
+}
+
+var workItemTreeFields = []string{
+	"System.Id",
+	"System.Rev",
+	"System.WorkItemType",
+	"System.Title",
+	"System.State",
+	"System.AssignedTo",
+	"Microsoft.VSTS.Scheduling.RemainingWork",
+}
+
+// workItemTreeSource loads work items of the tree and applies changes made in the tree.
+type workItemTreeSource struct {
+	ctx   context.Context
+	api   *tfs.API
+	rules []workItemTransitionRule
+	// revs are revisions of loaded work items to update them only if not changed meanwhile
+	revs map[int]int
+}
+
+func treeWorkItemCommand(ctx context.Context, workItemID int) error {
+	source := &workItemTreeSource{ctx: ctx, revs: make(map[int]int)}
+
+	err := viper.UnmarshalKey("workItemTransitionRules", &source.rules)
+	if err != nil {
+		return fmt.Errorf("invalid workItemTransitionRules: %w", err)
+	}
+
+	source.api, err = tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.WithRemoveWhenDone().Start("Loading...")
+	items, err := source.load([]int{workItemID})
+	_ = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	return tasksui.BrowseTree(items[0], source)
+}
+
+// load returns work items with remaining work rolled up from their subtrees.
+func (s *workItemTreeSource) load(workItemIDs []int) ([]*tasksui.TreeItem, error) {
+	links, err := s.api.WiClient.GetDescendantLinks(s.ctx, workItemIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	workItems, err := s.api.WiClient.GetList(s.ctx, workItemIDs, workItemTreeFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var descendantIDs []int
+	for _, childIDs := range links {
+		descendantIDs = append(descendantIDs, childIDs...)
+	}
+
+	remaining := make(map[int]float32)
+	if len(descendantIDs) > 0 {
+		descendants, err := s.api.WiClient.GetList(s.ctx, descendantIDs, []string{
+			"System.Id",
+			"Microsoft.VSTS.Scheduling.RemainingWork",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, wi := range descendants {
+			remaining[*wi.Id] = workitem.GetRemainingWork(&wi)
+		}
+	}
+
+	items := make([]*tasksui.TreeItem, 0, len(workItems))
+	for _, wi := range workItems {
+		item := &tasksui.TreeItem{ID: *wi.Id, HasChildren: len(links[*wi.Id]) > 0}
+		s.setItemFields(item, &wi)
+		item.Total = item.Remaining + getSubtreeRemainingWork(*wi.Id, links, remaining)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func getSubtreeRemainingWork(workItemID int, links map[int][]int, remaining map[int]float32) float32 {
+	var total float32
+	for _, childID := range links[workItemID] {
+		total += remaining[childID] + getSubtreeRemainingWork(childID, links, remaining)
+	}
+	return total
+}
+
+func (s *workItemTreeSource) setItemFields(item *tasksui.TreeItem, wi *workitemtracking.WorkItem) {
+	item.Type = workitem.GetType(wi)
+	item.Title = workitem.GetTitle(wi)
+	item.State = workitem.GetState(wi)
+	item.AssignedTo = formatWorkItemFieldValue(getWorkItemFieldValue(wi, "System.AssignedTo"))
+	item.Remaining = workitem.GetRemainingWork(wi)
+	if wi.Rev != nil {
+		s.revs[*wi.Id] = *wi.Rev
+	}
+}
+
+// refresh reloads fields of the item after changes made by the server.
+func (s *workItemTreeSource) refresh(item *tasksui.TreeItem) error {
+	workItems, err := s.api.WiClient.GetList(s.ctx, []int{item.ID}, workItemTreeFields)
+	if err != nil {
+		return err
+	}
+
+	s.setItemFields(item, &workItems[0])
+	return nil
+}
+
+func (s *workItemTreeSource) GetChildren(parent *tasksui.TreeItem) ([]*tasksui.TreeItem, error) {
+	childIDs, err := s.api.WiClient.GetChildIDs(s.ctx, parent.ID)
+	if err != nil || len(childIDs) == 0 {
+		return nil, err
+	}
+
+	return s.load(childIDs)
+}
+
+func (s *workItemTreeSource) Update(item *tasksui.TreeItem, title string, remaining float32) error {
+	fields := make(map[string]any)
+	if title != item.Title {
+		fields["System.Title"] = title
+	}
+	if remaining != item.Remaining {
+		fields["Microsoft.VSTS.Scheduling.RemainingWork"] = remaining
+	}
+
+	wi, err := s.api.WiClient.UpdateFieldsAtRevision(s.ctx, item.ID, s.revs[item.ID], fields)
+	if err != nil {
+		return err
+	}
+
+	s.setItemFields(item, wi)
+	return nil
+}
+
+func (s *workItemTreeSource) GetStates(item *tasksui.TreeItem) ([]string, error) {
+	transitions, err := s.api.WiClient.GetTypeTransitions(s.ctx, item.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []string
+	for from, to := range transitions {
+		for _, state := range slices.Concat(to, []string{from}) {
+			if state != "" && state != item.State && !slices.Contains(states, state) &&
+				workitem.FindTransitionPath(transitions, item.State, state) != nil {
+				states = append(states, state)
+			}
+		}
+	}
+	slices.Sort(states)
+
+	return states, nil
+}
+
+func (s *workItemTreeSource) SetState(item *tasksui.TreeItem, state string) error {
+	err := transitionWorkItem(s.ctx, s.api, &workItemTransitionResult{workItemID: item.ID}, state, workItemTransitionOptions{rules: s.rules})
+	if err != nil {
+		return err
+	}
+
+	return s.refresh(item)
+}
+
+func (s *workItemTreeSource) SetParent(item, parent *tasksui.TreeItem) error {
+	wi, err := s.api.WiClient.GetWorkItem(s.ctx, workitemtracking.GetWorkItemArgs{
+		Id:      &item.ID,
+		Project: &s.api.Project,
+		Expand:  &workitemtracking.WorkItemExpandValues.Relations,
+	})
+	if err != nil {
+		return err
+	}
+
+	parentWorkItem, err := s.api.WiClient.Get(s.ctx, parent.ID)
+	if err != nil {
+		return err
+	}
+
+	err = changeWorkItemParent(s.ctx, s.api, wi, parentWorkItem)
+	if err != nil {
+		return err
+	}
+
+	return s.refresh(item)
+}
+
+func (s *workItemTreeSource) Open(item *tasksui.TreeItem) error {
+	wi, err := s.api.WiClient.Get(s.ctx, item.ID)
+	if err != nil {
+		return err
+	}
+
+	return browser.OpenURL(workitem.GetURL(wi))
+}
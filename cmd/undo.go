@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"tasker/journal"
+	"tasker/tfs"
+	"tasker/wiki"
+
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoCmd = &cobra.Command{
+		Use:   "undo [Journal Entry ID]",
+		Short: "Revert changes made by commands",
+		Long: `Revert changes saved into the journal by the command run: field values, states, relations and parents of work items,
+versions of wiki pages. Created work items are moved into the recycle bin, deleted ones are restored or recreated.
+Created wiki pages are moved into the trash, deleted ones are restored from the trash.
+Without entry ID the latest not reverted commands are reverted (see journal command).
+Work items and pages changed after the command are not reverted without --force.`,
+		Example: `  tasker undo
+  tasker undo --last 3
+  tasker undo 42`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var entryID int
+			if len(args) > 0 {
+				var err error
+				entryID, err = strconv.Atoi(args[0])
+				cobra.CheckErr(err)
+			}
+
+			err := undoCommand(cmd.Context(), entryID)
+			cobra.CheckErr(err)
+		},
+	}
+
+	journalCmd = &cobra.Command{
+		Use:   "journal",
+		Short: "Show journal of changes",
+		Long:  "Show the latest entries of the local journal of changes made by commands, the entries are reverted by undo command.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := journalCommand()
+			cobra.CheckErr(err)
+		},
+	}
+
+	undoCmdFlagLast  int
+	undoCmdFlagForce bool
+	undoCmdFlagYes   bool
+
+	journalCmdFlagLimit int
+)
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(journalCmd)
+
+	undoCmd.Flags().IntVarP(&undoCmdFlagLast, "last", "n", 1, "Number of the latest commands to revert")
+	undoCmd.Flags().BoolVarP(&undoCmdFlagForce, "force", "f", false, "Revert work items and pages changed after the command")
+	undoCmd.Flags().BoolVarP(&undoCmdFlagYes, "yes", "y", false, "Revert without confirmation")
+
+	journalCmd.Flags().IntVarP(&journalCmdFlagLimit, "limit", "n", 20, "Number of the latest entries to show")
+}
+
+// undoTarget is the work item or page changed by the journal entry.
+type undoTarget struct {
+	workItemID int
+	pageID     string
+	changes    []journal.Change
+}
+
+func journalCommand() error {
+	entries, err := journal.Entries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("journal is empty")
+		return nil
+	}
+
+	tableData := [][]string{{"Entry", "Time", "Command", "Changes", "Undone By"}}
+	for _, entry := range entries[max(len(entries)-journalCmdFlagLimit, 0):] {
+		undoneBy := ""
+		if entry.UndoneBy != 0 {
+			undoneBy = strconv.Itoa(entry.UndoneBy)
+		}
+
+		tableData = append(tableData, []string{
+			strconv.Itoa(entry.ID),
+			entry.Time.Local().Format(time.DateTime),
+			cutString(entry.Command, 50, false),
+			strings.Join(lo.Map(getUndoTargets(entry), func(target *undoTarget, _ int) string {
+				return target.String()
+			}), ", "),
+			undoneBy,
+		})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+}
+
+func undoCommand(ctx context.Context, entryID int) error {
+	entries, err := journal.Entries()
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectUndoEntries(entries, entryID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range selected {
+		pterm.DefaultSection.Println(fmt.Sprintf("Entry %d, %s: tasker %s", entry.ID,
+			entry.Time.Local().Format(time.DateTime), entry.Command))
+		for _, target := range getUndoTargets(entry) {
+			fmt.Println("  " + target.describeUndo())
+		}
+	}
+
+	if !undoCmdFlagYes {
+		ok, err := requestConfirmationKey()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("canceled by user")
+		}
+	}
+
+	a, err := tfs.NewAPI(ctx)
+	if err != nil {
+		return err
+	}
+
+	var wikiAPI *wiki.API
+	var failed int
+	for _, entry := range selected {
+		targets := getUndoTargets(entry)
+		if wikiAPI == nil && slices.ContainsFunc(targets, func(target *undoTarget) bool { return target.pageID != "" }) {
+			wikiAPI, err = wiki.NewClient()
+			if err != nil {
+				return err
+			}
+		}
+
+		var entryFailed bool
+		// changes are reverted from the latest one
+		for _, target := range slices.Backward(targets) {
+			message, err := undoTargetChanges(ctx, a, wikiAPI, target)
+			if err != nil {
+				entryFailed = true
+				pterm.Error.Println(fmt.Sprintf("NOT REVERTED %s: %s", target.String(), err.Error()))
+				continue
+			}
+			pterm.Success.Println(message)
+		}
+
+		if entryFailed {
+			failed++
+			continue
+		}
+		journal.Add(journal.Change{Operation: journal.OperationUndo, Undone: entry.ID})
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d journal entries not reverted completely", failed)
+	}
+	return nil
+}
+
+// selectUndoEntries returns the entry by ID or the latest entries neither reverted nor reverting others,
+// from the latest one.
+func selectUndoEntries(entries []*journal.Entry, entryID int) ([]*journal.Entry, error) {
+	if entryID != 0 {
+		entry, ok := lo.Find(entries, func(entry *journal.Entry) bool { return entry.ID == entryID })
+		if !ok {
+			return nil, fmt.Errorf("journal entry %d not found", entryID)
+		}
+		if entry.UndoneBy != 0 {
+			return nil, fmt.Errorf("journal entry %d is already reverted by %d", entryID, entry.UndoneBy)
+		}
+		return []*journal.Entry{entry}, nil
+	}
+
+	var selected []*journal.Entry
+	for _, entry := range slices.Backward(entries) {
+		if len(selected) == undoCmdFlagLast {
+			break
+		}
+		if entry.UndoneBy == 0 && !entry.IsUndo() {
+			selected = append(selected, entry)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("nothing to revert")
+	}
+	return selected, nil
+}
+
+// getUndoTargets groups changes of the entry by work items and pages in order of their first changes.
+func getUndoTargets(entry *journal.Entry) []*undoTarget {
+	var targets []*undoTarget
+	for _, change := range entry.Changes {
+		if change.Operation == journal.OperationUndo {
+			continue
+		}
+
+		target, ok := lo.Find(targets, func(target *undoTarget) bool {
+			return target.workItemID == change.WorkItemID && target.pageID == change.PageID
+		})
+		if !ok {
+			target = &undoTarget{workItemID: change.WorkItemID, pageID: change.PageID}
+			targets = append(targets, target)
+		}
+		target.changes = append(target.changes, change)
+	}
+	return targets
+}
+
+func (t *undoTarget) String() string {
+	if t.pageID != "" {
+		return "page " + t.pageID
+	}
+	return strconv.Itoa(t.workItemID)
+}
+
+func (t *undoTarget) first() journal.Change {
+	return t.changes[0]
+}
+
+func (t *undoTarget) last() journal.Change {
+	return t.changes[len(t.changes)-1]
+}
+
+// getPreviousValues returns values of fields and relations before the first change of the target.
+func (t *undoTarget) getPreviousValues() (map[string]any, *[]journal.Relation) {
+	fields := make(map[string]any)
+	var relations *[]journal.Relation
+	for _, change := range t.changes {
+		if change.Operation != journal.OperationUpdate {
+			continue
+		}
+
+		for field, value := range change.Fields {
+			if _, ok := fields[field]; !ok {
+				fields[field] = value
+			}
+		}
+		if relations == nil {
+			relations = change.Relations
+		}
+	}
+	return fields, relations
+}
+
+func (t *undoTarget) describeUndo() string {
+	switch {
+	case t.pageID != "" && t.first().Operation == journal.OperationCreate:
+		return fmt.Sprintf("page %s: move created page into trash", t.pageID)
+	case t.pageID != "" && t.last().Operation == journal.OperationDelete && len(t.changes) == 1:
+		return fmt.Sprintf("page %s: restore from trash", t.pageID)
+	case t.pageID != "" && t.last().Operation == journal.OperationDelete:
+		return fmt.Sprintf("page %s: restore from trash and restore version %d", t.pageID, t.first().PageVersion)
+	case t.pageID != "":
+		return fmt.Sprintf("page %s: restore version %d", t.pageID, t.first().PageVersion)
+	case t.first().Operation == journal.OperationCreate:
+		return fmt.Sprintf("%d: move created work item into recycle bin", t.workItemID)
+	case t.first().Operation == journal.OperationRestore:
+		return fmt.Sprintf("%d: move restored work item into recycle bin", t.workItemID)
+	case t.last().Operation == journal.OperationDelete && t.last().Destroyed:
+		return fmt.Sprintf("%d: recreate destroyed %s '%s' without attachments and hierarchy links", t.workItemID, t.last().Type, t.last().Fields["System.Title"])
+	case t.last().Operation == journal.OperationDelete:
+		return fmt.Sprintf("%d: restore %s '%s' from recycle bin", t.workItemID, t.last().Type, t.last().Fields["System.Title"])
+	}
+
+	fields, relations := t.getPreviousValues()
+	var parts []string
+	if len(fields) > 0 {
+		parts = append(parts, "restore "+strings.Join(slices.Sorted(maps.Keys(fields)), ", "))
+	}
+	if relations != nil {
+		parts = append(parts, "restore relations")
+	}
+	return fmt.Sprintf("%d: %s", t.workItemID, strings.Join(parts, ", "))
+}
+
+// undoTargetChanges reverts changes of the target and returns the message describing the result.
+func undoTargetChanges(ctx context.Context, api *tfs.API, wikiAPI *wiki.API, target *undoTarget) (string, error) {
+	if target.pageID != "" {
+		return undoPageChanges(wikiAPI, target)
+	}
+
+	force := undoCmdFlagForce
+	switch {
+	case target.first().Operation == journal.OperationCreate || target.first().Operation == journal.OperationRestore:
+		err := api.WiClient.Delete(ctx, target.workItemID)
+		return fmt.Sprintf("MOVED INTO RECYCLE BIN %d", target.workItemID), err
+	case target.last().Operation == journal.OperationDelete && target.last().Destroyed:
+		fields, relations := target.getPreviousValues()
+		snapshot := target.last()
+		maps.Copy(snapshot.Fields, fields)
+		if relations == nil {
+			relations = snapshot.Relations
+		}
+
+		wi, err := api.WiClient.Recreate(ctx, snapshot.Fields, lo.FromPtr(relations))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("RECREATED %d as %d", target.workItemID, *wi.Id), nil
+	case target.last().Operation == journal.OperationDelete:
+		err := api.WiClient.Restore(ctx, target.workItemID)
+		if err != nil {
+			return "", err
+		}
+		if len(target.changes) == 1 {
+			return fmt.Sprintf("RESTORED %d", target.workItemID), nil
+		}
+
+		// changes made before deletion are reverted as well, the restoring changes the revision
+		force = true
+		target.changes = target.changes[:len(target.changes)-1]
+	}
+
+	current, err := api.WiClient.GetWithRelations(ctx, target.workItemID)
+	if err != nil {
+		return "", err
+	}
+
+	if !force && *current.Rev != target.last().Rev {
+		return "", fmt.Errorf("changed after the command (rev %d, expected %d), use --force", *current.Rev, target.last().Rev)
+	}
+
+	fields, relations := target.getPreviousValues()
+	_, err = api.WiClient.Revert(ctx, current, fields, relations)
+	return fmt.Sprintf("REVERTED %d", target.workItemID), err
+}
+
+func undoPageChanges(api *wiki.API, target *undoTarget) (string, error) {
+	force := undoCmdFlagForce
+	switch {
+	case target.first().Operation == journal.OperationCreate:
+		_, err := api.DelContent(target.pageID)
+		return fmt.Sprintf("MOVED INTO TRASH page %s", target.pageID), err
+	case target.last().Operation == journal.OperationDelete:
+		err := api.RestorePage(target.pageID)
+		if err != nil {
+			return "", err
+		}
+		if len(target.changes) == 1 {
+			return fmt.Sprintf("RESTORED page %s", target.pageID), nil
+		}
+
+		// changes made before deletion are reverted as well, the restoring changes the version
+		force = true
+		target.changes = target.changes[:len(target.changes)-1]
+	}
+
+	version, err := api.GetPageVersion(target.pageID)
+	if err != nil {
+		return "", err
+	}
+
+	expected := target.last().PageVersion + 1
+	if !force && version != expected {
+		return "", fmt.Errorf("changed after the command (version %d, expected %d), use --force", version, expected)
+	}
+
+	err = api.RestorePageVersion(target.pageID, target.first().PageVersion)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("RESTORED page %s version %d", target.pageID, target.first().PageVersion), nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"testing"
+
+	"tasker/journal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getUndoTargets(t *testing.T) {
+	entry := &journal.Entry{Changes: []journal.Change{
+		{Operation: journal.OperationUpdate, WorkItemID: 2},
+		{Operation: journal.OperationPage, PageID: "100", PageVersion: 3},
+		{Operation: journal.OperationUpdate, WorkItemID: 1},
+		{Operation: journal.OperationUpdate, WorkItemID: 2},
+		{Operation: journal.OperationUndo, Undone: 5},
+	}}
+
+	targets := getUndoTargets(entry)
+
+	assert.Equal(t, []string{"2", "page 100", "1"}, []string{targets[0].String(), targets[1].String(), targets[2].String()})
+	assert.Len(t, targets[0].changes, 2)
+	assert.Len(t, targets[1].changes, 1)
+	assert.Len(t, targets[2].changes, 1)
+}
+
+func Test_getPreviousValues(t *testing.T) {
+	relationsA := []journal.Relation{{Rel: "Related", URL: "https://tfs/workItems/1"}}
+	relationsB := []journal.Relation{}
+
+	tests := []struct {
+		name          string
+		changes       []journal.Change
+		wantFields    map[string]any
+		wantRelations *[]journal.Relation
+	}{
+		{
+			name: "single update",
+			changes: []journal.Change{
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Title": "a"}},
+			},
+			wantFields: map[string]any{"System.Title": "a"},
+		},
+		{
+			name: "earliest values win",
+			changes: []journal.Change{
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Title": "a", "System.State": "New"}},
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Title": "b", "Microsoft.VSTS.Common.Priority": 2.0}},
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.State": "Active"}},
+			},
+			wantFields: map[string]any{"System.Title": "a", "System.State": "New", "Microsoft.VSTS.Common.Priority": 2.0},
+		},
+		{
+			name: "added field reverted to nil",
+			changes: []journal.Change{
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Tags": nil}},
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Tags": "x"}},
+			},
+			wantFields: map[string]any{"System.Tags": nil},
+		},
+		{
+			name: "earliest relations",
+			changes: []journal.Change{
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Title": "a"}},
+				{Operation: journal.OperationUpdate, Relations: &relationsA},
+				{Operation: journal.OperationUpdate, Relations: &relationsB},
+			},
+			wantFields:    map[string]any{"System.Title": "a"},
+			wantRelations: &relationsA,
+		},
+		{
+			name: "other operations skipped",
+			changes: []journal.Change{
+				{Operation: journal.OperationCreate},
+				{Operation: journal.OperationUpdate, Fields: map[string]any{"System.Title": "a"}},
+				{Operation: journal.OperationDelete, Fields: map[string]any{"System.Title": "b"}, Relations: &relationsB},
+			},
+			wantFields: map[string]any{"System.Title": "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, relations := (&undoTarget{changes: tt.changes}).getPreviousValues()
+			assert.Equal(t, tt.wantFields, fields)
+			assert.Equal(t, tt.wantRelations, relations)
+		})
+	}
+}
+
+func Test_describeUndo_Pages(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []journal.Change
+		want    string
+	}{
+		{
+			name:    "created",
+			changes: []journal.Change{{Operation: journal.OperationCreate, PageID: "10"}},
+			want:    "page 10: move created page into trash",
+		},
+		{
+			name:    "deleted",
+			changes: []journal.Change{{Operation: journal.OperationDelete, PageID: "10"}},
+			want:    "page 10: restore from trash",
+		},
+		{
+			name: "updated and deleted",
+			changes: []journal.Change{
+				{Operation: journal.OperationPage, PageID: "10", PageVersion: 4},
+				{Operation: journal.OperationDelete, PageID: "10"},
+			},
+			want: "page 10: restore from trash and restore version 4",
+		},
+		{
+			name:    "updated",
+			changes: []journal.Change{{Operation: journal.OperationPage, PageID: "10", PageVersion: 4}},
+			want:    "page 10: restore version 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &undoTarget{pageID: "10", changes: tt.changes}
+			assert.Equal(t, tt.want, target.describeUndo())
+		})
+	}
+}
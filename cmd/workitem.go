@@ -1,21 +1,22 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
-	"strings"
 
 	"tasker/prettyprint"
 	"tasker/ptr"
 	"tasker/tfs"
 	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
 	"github.com/pterm/pterm"
-	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
@@ -42,8 +43,10 @@ var (
 	deleteWorkItemsCmd = &cobra.Command{
 		Use:   "delete <Work Item ID, ...>",
 		Short: "Delete work items",
-		Long:  "Delete work items by ID.",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Delete work items by ID moving them into the recycle bin (see restore and recycle-bin commands).
+Titles, types and child counts of the work items are shown before the confirmation.
+With --destroy flag the work items are deleted permanently.`,
+		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var workItemIDs []int
 
@@ -61,8 +64,10 @@ var (
 	copyWorkItemCmd = &cobra.Command{
 		Use:   "copy <Work Item ID>",
 		Short: "Copy work item",
-		Long:  "Copy work item by ID.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Copy work item by ID.
+With --recursive flag the whole tree of child work items is copied, each child copy is linked to its source as AffectedBy.
+Iteration and area paths specified by flags are applied to all copies.`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			workItemID, err := strconv.Atoi(args[0])
 			cobra.CheckErr(err)
@@ -90,7 +95,7 @@ var (
 	closeWorkItemsCmd = &cobra.Command{
 		Use:   "close <Work Item ID, ...>",
 		Short: "Close work items",
-		Long:  "Close work items by ID.",
+		Long:  "Close work items by ID passing intermediate states if required (see transition command).",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var workItemIDs []int
@@ -101,7 +106,7 @@ var (
 				workItemIDs = append(workItemIDs, workItemID)
 			}
 
-			err := closeWorkItemsCommand(cmd.Context(), workItemIDs)
+			err := closeWorkItemsCommand(cmd.Context(), workItemIDs, closeWorkItemsCmdFlagComment)
 			cobra.CheckErr(err)
 		},
 	}
@@ -125,15 +130,26 @@ var (
 		},
 	}
 
+	deleteWorkItemsCmdFlagDestroy bool
+	deleteWorkItemsCmdFlagYes     bool
+
 	copyWorkItemCmdParentID      int
 	copyWorkItemCmdIterationPath string
 	copyWorkItemCmdAreaPath      string
+	copyWorkItemCmdRecursive     bool
+	copyWorkItemCmdResetState    bool
 
 	queryWorkItemsCmdFlagParent string
 	queryWorkItemsCmdFlagType   string
 	queryWorkItemsCmdFlagStates []string
 	queryWorkItemsCmdFlagActive bool
 	queryWorkItemsCmdFlagTags   []string
+	queryWorkItemsCmdFlagFields []string
+	queryWorkItemsCmdFlagOutput string
+	queryWorkItemsCmdFlagSort   string
+	queryWorkItemsCmdFlagGroup  string
+
+	closeWorkItemsCmdFlagComment string
 
 	changeWorkItemsParentCmdParentID int
 )
@@ -146,15 +162,26 @@ func init() {
 	rootCmd.AddCommand(closeWorkItemsCmd)
 	rootCmd.AddCommand(changeWorkItemsParentCmd)
 
+	deleteWorkItemsCmd.Flags().BoolVarP(&deleteWorkItemsCmdFlagDestroy, "destroy", "", false, "Delete permanently instead of moving into the recycle bin")
+	deleteWorkItemsCmd.Flags().BoolVarP(&deleteWorkItemsCmdFlagYes, "yes", "y", false, "Delete without confirmation")
+
 	copyWorkItemCmd.Flags().IntVarP(&copyWorkItemCmdParentID, "parent", "p", 0, "Id of parent of new Work Item (if specified, then source added as AffectedBy)")
 	copyWorkItemCmd.Flags().StringVarP(&copyWorkItemCmdIterationPath, "iteration", "i", "", "Iteration Path of new Work Item")
-	copyWorkItemCmd.Flags().StringVarP(&copyWorkItemCmdIterationPath, "area", "a", "", "Area Path of new Work Item")
+	copyWorkItemCmd.Flags().StringVarP(&copyWorkItemCmdAreaPath, "area", "a", "", "Area Path of new Work Item")
+	copyWorkItemCmd.Flags().BoolVarP(&copyWorkItemCmdRecursive, "recursive", "r", false, "Copy child work items preserving hierarchy")
+	copyWorkItemCmd.Flags().BoolVarP(&copyWorkItemCmdResetState, "reset", "", false, "Create copies in the initial state with remaining work equal to original estimate")
 
 	queryWorkItemsCmd.Flags().StringVarP(&queryWorkItemsCmdFlagParent, "parent", "p", "", "Work items child of specified work item")
 	queryWorkItemsCmd.Flags().StringVarP(&queryWorkItemsCmdFlagType, "type", "t", "", "Work items specified type")
 	queryWorkItemsCmd.Flags().StringSliceVarP(&queryWorkItemsCmdFlagStates, "state", "s", nil, "Work items in specified states")
 	queryWorkItemsCmd.Flags().BoolVarP(&queryWorkItemsCmdFlagActive, "active", "a", false, "Work items in active state")
 	queryWorkItemsCmd.Flags().StringSliceVarP(&queryWorkItemsCmdFlagTags, "tag", "", nil, "Work items tag")
+	queryWorkItemsCmd.Flags().StringSliceVarP(&queryWorkItemsCmdFlagFields, "fields", "f", nil, "Fields to print, friendly (Title, AssignedTo, RemainingWork) or reference names (only IDs are printed if neither fields nor output specified)")
+	queryWorkItemsCmd.Flags().StringVarP(&queryWorkItemsCmdFlagOutput, "output", "o", "", "Output format (table, json, csv, markdown)")
+	queryWorkItemsCmd.Flags().StringVarP(&queryWorkItemsCmdFlagSort, "sort", "", "", "Field to sort by, prefix with '-' for descending order")
+	queryWorkItemsCmd.Flags().StringVarP(&queryWorkItemsCmdFlagGroup, "group-by", "g", "", "Field to group by")
+
+	closeWorkItemsCmd.Flags().StringVarP(&closeWorkItemsCmdFlagComment, "comment", "c", "", "Comment (Markdown, @mentions) added to the discussion of closed work items")
 
 	changeWorkItemsParentCmd.Flags().IntVarP(&changeWorkItemsParentCmdParentID, "parent", "p", 0, "ID of new parent work item")
 	cobra.CheckErr(changeWorkItemsParentCmd.MarkFlagRequired("parent"))
@@ -194,34 +221,7 @@ func changeWorkItemsParentCommand(ctx context.Context, workItemIDs []int) error
 			progressbar.UpdateTitle(fmt.Sprintf("Processing %s", workitem.GetTitle(&workItem)))
 		}
 
-		relationIndex := slices.IndexFunc(*workItem.Relations, func(rel workitemtracking.WorkItemRelation) bool {
-			return *rel.Rel == "System.LinkTypes.Hierarchy-Reverse"
-		})
-		if relationIndex == -1 {
-			relationIndex = 0
-		}
-
-		fields := []webapi.JsonPatchOperation{
-			{
-				Op:   &webapi.OperationValues.Remove,
-				Path: ptr.FromStr(fmt.Sprintf("/relations/%d", relationIndex)),
-			},
-			{
-				Op:   &webapi.OperationValues.Add,
-				Path: ptr.FromStr("/relations/-"),
-				Value: workitemtracking.WorkItemRelation{
-					Rel: ptr.FromStr("System.LinkTypes.Hierarchy-Reverse"),
-					Url: newParentWorkItem.Url,
-				},
-			},
-		}
-
-		_, err := a.WiClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
-			Id:       workItem.Id,
-			Project:  &a.Project,
-			Document: &fields,
-		})
-
+		err := changeWorkItemParent(ctx, a, &workItem, newParentWorkItem)
 		if err != nil {
 			return err
 		}
@@ -234,50 +234,40 @@ func changeWorkItemsParentCommand(ctx context.Context, workItemIDs []int) error
 	return nil
 }
 
-func closeWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
-	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(workItemIDs)).WithRemoveWhenDone().Start()
-	if err == nil {
-		defer func() {
-			_, _ = progressbar.Stop()
-		}()
-	} else {
-		progressbar = nil
-	}
-
-	a, err := tfs.NewAPI(ctx)
-	if err != nil {
-		return err
-	}
-
-	for _, workItemID := range workItemIDs {
-		if progressbar != nil {
-			progressbar.UpdateTitle(fmt.Sprintf("Processing %d", workItemID))
-		}
-
-		fields := []webapi.JsonPatchOperation{
-			{
-				Op:    &webapi.OperationValues.Replace,
-				Path:  ptr.FromStr("/fields/System.State"),
-				Value: "Closed",
-			},
-		}
-
-		_, err := a.WiClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
-			Id:       ptr.FromInt(workItemID),
-			Project:  &a.Project,
-			Document: &fields,
+// changeWorkItemParent replaces the parent link of the work item loaded with relations.
+func changeWorkItemParent(ctx context.Context, api *tfs.API, workItem, newParentWorkItem *workitemtracking.WorkItem) error {
+	var fields []webapi.JsonPatchOperation
+	if workItem.Relations != nil {
+		relationIndex := slices.IndexFunc(*workItem.Relations, func(rel workitemtracking.WorkItemRelation) bool {
+			return *rel.Rel == "System.LinkTypes.Hierarchy-Reverse"
 		})
-
-		if err != nil {
-			return err
-		}
-
-		if progressbar != nil {
-			progressbar.Increment()
+		if relationIndex >= 0 {
+			fields = append(fields, webapi.JsonPatchOperation{
+				Op:   &webapi.OperationValues.Remove,
+				Path: ptr.FromStr(fmt.Sprintf("/relations/%d", relationIndex)),
+			})
 		}
 	}
 
-	return nil
+	fields = append(fields, webapi.JsonPatchOperation{
+		Op:   &webapi.OperationValues.Add,
+		Path: ptr.FromStr("/relations/-"),
+		Value: workitemtracking.WorkItemRelation{
+			Rel: ptr.FromStr("System.LinkTypes.Hierarchy-Reverse"),
+			Url: newParentWorkItem.Url,
+		},
+	})
+
+	_, err := api.WiClient.UpdateWorkItem(workitem.WithPrevious(ctx, workItem), workitemtracking.UpdateWorkItemArgs{
+		Id:       workItem.Id,
+		Project:  &api.Project,
+		Document: &fields,
+	})
+	return err
+}
+
+func closeWorkItemsCommand(ctx context.Context, workItemIDs []int, comment string) error {
+	return transitionWorkItemsCommand(ctx, workItemIDs, "Closed", workItemTransitionOptions{comment: comment})
 }
 
 func copyWorkItemsCommand(ctx context.Context, sourceWorkItemID int) error {
@@ -296,18 +286,16 @@ func copyWorkItemsCommand(ctx context.Context, sourceWorkItemID int) error {
 		return err
 	}
 
-	areaPath := copyWorkItemCmdAreaPath
-	if areaPath == "" {
-		areaPath = workitem.GetAreaPath(sourceWorkItem)
-	}
-
-	iterationPath := copyWorkItemCmdIterationPath
-	if iterationPath == "" {
-		iterationPath = workitem.GetIterationPath(sourceWorkItem)
+	// the tree is read before creating copies, so the copies never get into it
+	var descendantLinks map[int][]int
+	if copyWorkItemCmdRecursive {
+		descendantLinks, err = a.WiClient.GetDescendantLinks(ctx, sourceWorkItemID)
+		if err != nil {
+			return err
+		}
 	}
 
 	var relations []*workitem.Relation
-
 	if copyWorkItemCmdParentID != 0 {
 		parent, err := a.WiClient.Get(ctx, copyWorkItemCmdParentID)
 		if err != nil {
@@ -318,10 +306,7 @@ func copyWorkItemsCommand(ctx context.Context, sourceWorkItemID int) error {
 			URL:  *parent.Url,
 			Type: "System.LinkTypes.Hierarchy-Reverse",
 		})
-		relations = append(relations, &workitem.Relation{
-			URL:  *sourceWorkItem.Url,
-			Type: "Microsoft.VSTS.Common.Affects-Reverse",
-		})
+		relations = append(relations, getCopiedFromRelation(sourceWorkItem))
 	} else {
 		relations = append(relations, &workitem.Relation{
 			URL:  *sourceWorkItem.Url,
@@ -329,12 +314,81 @@ func copyWorkItemsCommand(ctx context.Context, sourceWorkItemID int) error {
 		})
 	}
 
-	tags := workitem.GetTags(sourceWorkItem)
-	task, err := a.WiClient.Copy(ctx, sourceWorkItem, areaPath, iterationPath, relations, tags)
-	printCreateTaskResult(task, err, spinner)
+	task, err := copyWorkItem(ctx, a, sourceWorkItem, relations)
+	if err != nil || !copyWorkItemCmdRecursive {
+		printCreateTaskResult(task, err, spinner)
+		openInBrowser(task)
+		return err
+	}
+
+	count, err := copyWorkItemChildren(ctx, a, descendantLinks, sourceWorkItemID, task, spinner)
+	if err != nil {
+		spinner.Warning(fmt.Sprintf("%s (%d children copied)", workitem.GetURL(task), count))
+		return err
+	}
+
+	spinner.Success(fmt.Sprintf("%s (%d children copied)", workitem.GetURL(task), count))
 	openInBrowser(task)
 
-	return err
+	return nil
+}
+
+func copyWorkItem(ctx context.Context, api *tfs.API, source *workitemtracking.WorkItem, relations []*workitem.Relation) (*workitemtracking.WorkItem, error) {
+	areaPath := copyWorkItemCmdAreaPath
+	if areaPath == "" {
+		areaPath = workitem.GetAreaPath(source)
+	}
+
+	iterationPath := copyWorkItemCmdIterationPath
+	if iterationPath == "" {
+		iterationPath = workitem.GetIterationPath(source)
+	}
+
+	return api.WiClient.Copy(ctx, source, areaPath, iterationPath, relations, workitem.GetTags(source),
+		workitem.CopyResetState(copyWorkItemCmdResetState))
+}
+
+// copyWorkItemChildren copies the tree of children of the source (children IDs by parent ID) under the copy
+// of the source and returns the number of copied work items.
+func copyWorkItemChildren(ctx context.Context, api *tfs.API, links map[int][]int, sourceID int, target *workitemtracking.WorkItem, spinner *pterm.SpinnerPrinter) (int, error) {
+	var count int
+	for _, childID := range links[sourceID] {
+		child, err := api.WiClient.Get(ctx, childID)
+		if err != nil {
+			return count, err
+		}
+
+		spinner.UpdateText(fmt.Sprintf("Copying %d %s", childID, workitem.GetTitle(child)))
+
+		copied, err := copyWorkItem(ctx, api, child, []*workitem.Relation{
+			{
+				URL:  *target.Url,
+				Type: "System.LinkTypes.Hierarchy-Reverse",
+			},
+			getCopiedFromRelation(child),
+		})
+		if err != nil {
+			return count, fmt.Errorf("copy %d: %w", childID, err)
+		}
+		count++
+
+		copiedChildren, err := copyWorkItemChildren(ctx, api, links, childID, copied, spinner)
+		count += copiedChildren
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// getCopiedFromRelation links the copy with the source as AffectedBy.
+func getCopiedFromRelation(source *workitemtracking.WorkItem) *workitem.Relation {
+	return &workitem.Relation{
+		URL:     *source.Url,
+		Type:    "Microsoft.VSTS.Common.Affects-Reverse",
+		Comment: fmt.Sprintf("Copied from #%d", *source.Id),
+	}
 }
 
 func getWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
@@ -362,86 +416,72 @@ func queryWorkItemCommand(ctx context.Context, titlePattern string) error {
 	var workItems []int
 
 	if queryWorkItemsCmdFlagParent != "" {
-		childsWiql := `
-			SELECT
-				[System.Id]
-			FROM WorkItemLinks
-			WHERE 
-				([System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Reverse')
-				AND ([Target].[System.Id] = ` + queryWorkItemsCmdFlagParent + `)
-		`
-
-		childsResult, err := a.WiClient.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
-			Wiql: &workitemtracking.Wiql{
-				Query: ptr.FromStr(childsWiql),
-			},
-			Project: &a.Project,
-			Team:    &a.Team,
-		})
+		parentID, err := strconv.Atoi(queryWorkItemsCmdFlagParent)
+		if err != nil {
+			return fmt.Errorf("invalid parent work item ID '%s': %w", queryWorkItemsCmdFlagParent, err)
+		}
 
+		workItems, err = a.WiClient.GetChildIDs(ctx, parentID)
 		if err != nil {
 			return err
 		}
 
-		workItems = lo.Map(*childsResult.WorkItemRelations, func(wi workitemtracking.WorkItemLink, _ int) int {
-			return *wi.Target.Id
-		})
-
 		if len(workItems) == 0 {
 			return nil
 		}
 	}
 
-	var filters []string
+	var filters []wiql.Condition
 	if titlePattern != "" {
-		filters = append(filters, "[Title] CONTAINS '"+titlePattern+"'")
+		filters = append(filters, wiql.Contains("System.Title", titlePattern))
 	}
 
 	if queryWorkItemsCmdFlagActive {
-		filters = append(filters, "[State] = 'Active'")
+		filters = append(filters, wiql.Eq("System.State", "Active"))
 	}
 
 	if queryWorkItemsCmdFlagType != "" {
-		filters = append(filters, "[Work Item Type] = '"+queryWorkItemsCmdFlagType+"'")
+		filters = append(filters, wiql.Eq("System.WorkItemType", queryWorkItemsCmdFlagType))
 	}
 
-	var statesFilters []string
-	for _, state := range queryWorkItemsCmdFlagStates {
-		statesFilters = append(statesFilters, "[State] = '"+state+"'")
-	}
-	if len(statesFilters) > 0 {
-		filters = append(filters, "("+strings.Join(statesFilters, " AND ")+")")
+	if len(queryWorkItemsCmdFlagStates) > 0 {
+		filters = append(filters, wiql.In("System.State", queryWorkItemsCmdFlagStates...))
 	}
 
-	if len(workItems) > 0 {
-		filters = append(filters, "[Id] IN ("+strings.Join(lo.Map(workItems, func(id int, _ int) string { return strconv.Itoa(id) }), ",")+")")
+	for _, tag := range queryWorkItemsCmdFlagTags {
+		filters = append(filters, wiql.Contains("System.Tags", tag))
 	}
 
 	if len(filters) > 0 {
-		wiql := "SELECT [Id] FROM WorkItems WHERE " + strings.Join(filters, " AND ")
-
-		result, err := a.WiClient.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
-			Wiql: &workitemtracking.Wiql{
-				Query: ptr.FromStr(wiql),
-			},
-			Project: &a.Project,
-			Team:    &a.Team,
-		})
+		if len(workItems) > 0 {
+			filters = append(filters, wiql.In("System.Id", workItems...))
+		}
 
+		var err error
+		workItems, err = a.WiClient.QueryIDs(ctx, wiql.Select("System.Id").Where(filters...))
 		if err != nil {
 			return err
 		}
+	}
 
-		workItems = lo.Map(*result.WorkItems, func(wi workitemtracking.WorkItemReference, _ int) int {
-			return *wi.Id
-		})
+	if len(queryWorkItemsCmdFlagFields) == 0 && queryWorkItemsCmdFlagOutput == "" {
+		for _, id := range workItems {
+			fmt.Printf("%v\n", id)
+		}
+		return nil
 	}
 
-	for _, id := range workItems {
-		fmt.Printf("%v\n", id)
+	fields := queryWorkItemsCmdFlagFields
+	if len(fields) == 0 {
+		fields = []string{"Type", "Title", "State", "AssignedTo"}
 	}
 
-	return nil
+	return printWorkItems(ctx, a, workItems, workItemsOutputOptions{
+		fields:  fields,
+		format:  cmp.Or(queryWorkItemsCmdFlagOutput, outputFormatTable),
+		sortBy:  queryWorkItemsCmdFlagSort,
+		groupBy: queryWorkItemsCmdFlagGroup,
+	})
 }
 
 func deleteWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
@@ -450,6 +490,22 @@ func deleteWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
 		return err
 	}
 
+	err = previewDeletedWorkItems(ctx, a, workItemIDs)
+	if err != nil {
+		return err
+	}
+
+	if !deleteWorkItemsCmdFlagYes {
+		ok, err := requestConfirmationKey()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("canceled by user")
+		}
+	}
+
 	progressbar, err := pterm.DefaultProgressbar.WithTitle("Processing...").WithTotal(len(workItemIDs)).WithRemoveWhenDone().Start()
 	if err == nil {
 		defer func() {
@@ -464,7 +520,11 @@ func deleteWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
 			progressbar.UpdateTitle(fmt.Sprintf("Processing %d", workItemID))
 		}
 
-		err := a.WiClient.Delete(ctx, workItemID)
+		if deleteWorkItemsCmdFlagDestroy {
+			err = a.WiClient.Destroy(ctx, workItemID)
+		} else {
+			err = a.WiClient.Delete(ctx, workItemID)
+		}
 		if err != nil {
 			return err
 		}
@@ -474,5 +534,54 @@ func deleteWorkItemsCommand(ctx context.Context, workItemIDs []int) error {
 		}
 	}
 
+	if deleteWorkItemsCmdFlagDestroy {
+		pterm.Success.Println(fmt.Sprintf("%d work items deleted permanently", len(workItemIDs)))
+	} else {
+		pterm.Success.Println(fmt.Sprintf("%d work items moved into the recycle bin, use restore command to get them back", len(workItemIDs)))
+	}
+	return nil
+}
+
+// previewDeletedWorkItems prints the work items going to be deleted with counts of their children,
+// which are not deleted but lose the parent.
+func previewDeletedWorkItems(ctx context.Context, a *tfs.API, workItemIDs []int) error {
+	workItems, err := a.WiClient.GetList(ctx, workItemIDs, []string{"System.Id", "System.WorkItemType", "System.Title", "System.State"})
+	if err != nil {
+		return err
+	}
+
+	links, err := a.WiClient.GetChildLinks(ctx, workItemIDs...)
+	if err != nil {
+		return err
+	}
+
+	var withChildren int
+	tableData := [][]string{{"ID", "Type", "Title", "State", "Children"}}
+	for _, wi := range workItems {
+		children := len(links[*wi.Id])
+		if children > 0 {
+			withChildren++
+		}
+
+		tableData = append(tableData, []string{
+			strconv.Itoa(*wi.Id),
+			workitem.GetType(&wi),
+			cutString(workitem.GetTitle(&wi), 60, false),
+			workitem.GetState(&wi),
+			strconv.Itoa(children),
+		})
+	}
+
+	err = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	if err != nil {
+		return err
+	}
+
+	if withChildren > 0 {
+		pterm.Warning.Println(fmt.Sprintf("%d work items have children, the children are not deleted and lose the parent", withChildren))
+	}
+	if deleteWorkItemsCmdFlagDestroy {
+		pterm.Warning.Println("work items will be deleted permanently and can't be restored from the recycle bin")
+	}
 	return nil
 }
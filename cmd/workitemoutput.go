@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/tfs"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/pterm/pterm"
+	"github.com/samber/lo"
+)
+
+// workItemFieldAliases maps friendly field names to reference names.
+var workItemFieldAliases = map[string]string{
+	"id":               "System.Id",
+	"title":            "System.Title",
+	"state":            "System.State",
+	"reason":           "System.Reason",
+	"type":             "System.WorkItemType",
+	"workitemtype":     "System.WorkItemType",
+	"assignedto":       "System.AssignedTo",
+	"createdby":        "System.CreatedBy",
+	"createddate":      "System.CreatedDate",
+	"changedby":        "System.ChangedBy",
+	"changeddate":      "System.ChangedDate",
+	"areapath":         "System.AreaPath",
+	"area":             "System.AreaPath",
+	"iterationpath":    "System.IterationPath",
+	"iteration":        "System.IterationPath",
+	"tags":             "System.Tags",
+	"parent":           "System.Parent",
+	"description":      "System.Description",
+	"rev":              "System.Rev",
+	"priority":         "Microsoft.VSTS.Common.Priority",
+	"activity":         "Microsoft.VSTS.Common.Activity",
+	"originalestimate": "Microsoft.VSTS.Scheduling.OriginalEstimate",
+	"remainingwork":    "Microsoft.VSTS.Scheduling.RemainingWork",
+	"completedwork":    "Microsoft.VSTS.Scheduling.CompletedWork",
+	"startdate":        "Microsoft.VSTS.Scheduling.StartDate",
+	"finishdate":       "Microsoft.VSTS.Scheduling.FinishDate",
+}
+
+// getWorkItemFieldReferenceName returns reference name of the field by friendly name,
+// names containing dots are considered reference names already.
+func getWorkItemFieldReferenceName(name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	if referenceName, ok := workItemFieldAliases[strings.ToLower(name)]; ok {
+		return referenceName
+	}
+	return "System." + name
+}
+
+func getWorkItemFieldValue(wi *workitemtracking.WorkItem, referenceName string) any {
+	if referenceName == "System.Id" && wi.Id != nil {
+		return *wi.Id
+	}
+	if wi.Fields == nil {
+		return nil
+	}
+	return (*wi.Fields)[referenceName]
+}
+
+func formatWorkItemFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		// identity fields
+		if displayName, ok := v["displayName"].(string); ok {
+			return displayName
+		}
+		return fmt.Sprintf("%v", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func compareWorkItemFieldValues(a, b any) int {
+	af, aok := a.(float64)
+	bf, bok := b.(float64)
+	if aok && bok {
+		return cmp.Compare(af, bf)
+	}
+	ai, aok := a.(int)
+	bi, bok := b.(int)
+	if aok && bok {
+		return cmp.Compare(ai, bi)
+	}
+	return strings.Compare(formatWorkItemFieldValue(a), formatWorkItemFieldValue(b))
+}
+
+type workItemsOutputOptions struct {
+	fields  []string
+	format  string
+	sortBy  string
+	groupBy string
+}
+
+// printWorkItems requests the fields of the work items and prints them in the specified format.
+// Sort field can be prefixed with "-" for descending order.
+func printWorkItems(ctx context.Context, api *tfs.API, workItemIDs []int, options workItemsOutputOptions) error {
+	err := checkOutputFormat(options.format)
+	if err != nil {
+		return err
+	}
+
+	sortBy, descending := strings.CutPrefix(options.sortBy, "-")
+	columns := lo.Uniq(append([]string{"Id"}, options.fields...))
+	requested := lo.Uniq(lo.Map(append(slices.Clone(columns), lo.Compact([]string{sortBy, options.groupBy})...), func(name string, _ int) string {
+		return getWorkItemFieldReferenceName(name)
+	}))
+
+	workItems, err := api.WiClient.GetList(ctx, workItemIDs, requested)
+	if err != nil {
+		return err
+	}
+
+	if sortBy != "" {
+		sortField := getWorkItemFieldReferenceName(sortBy)
+		slices.SortStableFunc(workItems, func(a, b workitemtracking.WorkItem) int {
+			result := compareWorkItemFieldValues(getWorkItemFieldValue(&a, sortField), getWorkItemFieldValue(&b, sortField))
+			if descending {
+				return -result
+			}
+			return result
+		})
+	}
+
+	groups := map[string][]workitemtracking.WorkItem{"": workItems}
+	groupNames := []string{""}
+	if options.groupBy != "" {
+		groupField := getWorkItemFieldReferenceName(options.groupBy)
+		groups = lo.GroupBy(workItems, func(wi workitemtracking.WorkItem) string {
+			return formatWorkItemFieldValue(getWorkItemFieldValue(&wi, groupField))
+		})
+		groupNames = lo.Keys(groups)
+		slices.Sort(groupNames)
+	}
+
+	if options.format == outputFormatJSON {
+		toRecords := func(workItems []workitemtracking.WorkItem) []map[string]any {
+			return lo.Map(workItems, func(wi workitemtracking.WorkItem, _ int) map[string]any {
+				return lo.SliceToMap(columns, func(column string) (string, any) {
+					return column, getWorkItemFieldValue(&wi, getWorkItemFieldReferenceName(column))
+				})
+			})
+		}
+
+		if options.groupBy == "" {
+			return printJSON(toRecords(workItems))
+		}
+		return printJSON(lo.MapValues(groups, func(workItems []workitemtracking.WorkItem, _ string) []map[string]any {
+			return toRecords(workItems)
+		}))
+	}
+
+	for _, groupName := range groupNames {
+		data := [][]string{columns}
+		for _, wi := range groups[groupName] {
+			data = append(data, lo.Map(columns, func(column string, _ int) string {
+				return formatWorkItemFieldValue(getWorkItemFieldValue(&wi, getWorkItemFieldReferenceName(column)))
+			}))
+		}
+
+		if options.groupBy != "" && options.format != outputFormatCSV {
+			title := fmt.Sprintf("%s: %s (%d)", options.groupBy, lo.Ternary(groupName == "", "<none>", groupName), len(data)-1)
+			if options.format == outputFormatMarkdown {
+				fmt.Printf("\n## %s\n\n", title)
+			} else {
+				pterm.DefaultSection.Println(title)
+			}
+		}
+
+		if options.format == outputFormatCSV && groupName != groupNames[0] {
+			// single csv document: header only once
+			data = data[1:]
+		}
+
+		err := printTable(options.format, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Edit opens the text in the editor from VISUAL or EDITOR environment variables and returns the edited text.
+func Edit(text string) (string, error) {
+	file, err := os.CreateTemp("", "tasker-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	_, err = file.WriteString(text)
+	if err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	err = file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	args := append(strings.Fields(getEditor()), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func getEditor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
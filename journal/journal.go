@@ -0,0 +1,215 @@
+// Package journal keeps the local log of changes made by commands so they can be reverted.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/viper"
+)
+
+// Operations of journal records.
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+	OperationPage    = "page"
+	OperationUndo    = "undo"
+)
+
+const defaultFileName = ".tasker.journal"
+
+// Relation is a relation of work item saved in the journal.
+type Relation struct {
+	Rel        string         `json:"rel"`
+	URL        string         `json:"url"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// Change is a record of the journal, changes made by one run of a command share the entry ID.
+type Change struct {
+	Entry      int       `json:"entry"`
+	Command    string    `json:"command"`
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	WorkItemID int       `json:"workItemId,omitempty"`
+	// Rev is the revision of the work item after the change
+	Rev int `json:"rev,omitempty"`
+	// Type is the type of the deleted work item
+	Type string `json:"type,omitempty"`
+	// Fields are previous values of the changed fields, all fields of the deleted work item
+	Fields map[string]any `json:"fields,omitempty"`
+	// Relations are previous relations if they were changed, all relations of the deleted work item
+	Relations *[]Relation `json:"relations,omitempty"`
+	// Destroyed is set if the work item was deleted permanently instead of moving into the recycle bin
+	Destroyed bool   `json:"destroyed,omitempty"`
+	PageID    string `json:"pageId,omitempty"`
+	// PageVersion is the version of the page before the change
+	PageVersion int `json:"pageVersion,omitempty"`
+	// Undone is the entry reverted by the undo record
+	Undone int `json:"undone,omitempty"`
+}
+
+// Entry is the group of changes made by one run of a command.
+type Entry struct {
+	ID      int
+	Command string
+	Time    time.Time
+	Changes []Change
+	// UndoneBy is the ID of the entry reverted this one
+	UndoneBy int
+}
+
+var (
+	mutex   sync.Mutex
+	entryID int
+)
+
+// Path returns the path of the journal file, journalFile setting or .tasker.journal in the home directory.
+func Path() (string, error) {
+	if path := viper.GetString("journalFile"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, defaultFileName), nil
+}
+
+// Add appends the change to the entry of the current command run, the journal failure is only reported
+// as the change is already made.
+func Add(change Change) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	err := add(change)
+	if err != nil {
+		pterm.Warning.Println(fmt.Sprintf("change is not saved into the journal: %s", err.Error()))
+	}
+}
+
+func add(change Change) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if entryID == 0 {
+		changes, err := read(path)
+		if err != nil {
+			return err
+		}
+
+		entryID = 1
+		if len(changes) > 0 {
+			entryID = changes[len(changes)-1].Entry + 1
+		}
+	}
+
+	change.Entry = entryID
+	change.Command = strings.Join(os.Args[1:], " ")
+	change.Time = time.Now()
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func read(path string) ([]Change, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var changes []Change
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var change Change
+		err := json.Unmarshal(scanner.Bytes(), &change)
+		if err != nil {
+			return nil, fmt.Errorf("invalid journal %s: %w", path, err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, scanner.Err()
+}
+
+// Entries returns entries of the journal from the oldest one.
+func Entries() ([]*Entry, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	byID := make(map[int]*Entry)
+	for _, change := range changes {
+		entry, ok := byID[change.Entry]
+		if !ok {
+			entry = &Entry{ID: change.Entry, Command: change.Command, Time: change.Time}
+			byID[change.Entry] = entry
+			entries = append(entries, entry)
+		}
+
+		if change.Operation == OperationUndo {
+			if undone, ok := byID[change.Undone]; ok {
+				undone.UndoneBy = change.Entry
+			}
+		}
+		entry.Changes = append(entry.Changes, change)
+	}
+
+	return entries, nil
+}
+
+// IsUndo reports whether the entry reverts another one.
+func (e *Entry) IsUndo() bool {
+	return slices.ContainsFunc(e.Changes, func(change Change) bool {
+		return change.Operation == OperationUndo
+	})
+}
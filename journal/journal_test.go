@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setJournalFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "journal")
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	viper.Set("journalFile", path)
+	entryID = 0
+	t.Cleanup(func() {
+		viper.Set("journalFile", "")
+		entryID = 0
+	})
+	return path
+}
+
+func Test_Entries(t *testing.T) {
+	setJournalFile(t, `{"entry":1,"command":"set 5","time":"2026-10-01T10:00:00Z","operation":"update","workItemId":5,"rev":3,"fields":{"System.Title":"a"}}
+{"entry":1,"command":"set 5","time":"2026-10-01T10:00:00Z","operation":"update","workItemId":6,"rev":7}
+
+{"entry":2,"command":"delete 7","time":"2026-10-01T11:00:00Z","operation":"delete","workItemId":7,"destroyed":true}
+{"entry":3,"command":"undo","time":"2026-10-01T12:00:00Z","operation":"update","workItemId":5,"rev":4}
+{"entry":3,"command":"undo","time":"2026-10-01T12:00:00Z","operation":"undo","undone":1}
+`)
+
+	entries, err := Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	tests := []struct {
+		id       int
+		command  string
+		changes  int
+		undoneBy int
+		isUndo   bool
+	}{
+		{id: 1, command: "set 5", changes: 2, undoneBy: 3},
+		{id: 2, command: "delete 7", changes: 1},
+		{id: 3, command: "undo", changes: 2, isUndo: true},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.id, entries[i].ID)
+		assert.Equal(t, tt.command, entries[i].Command)
+		assert.Len(t, entries[i].Changes, tt.changes)
+		assert.Equal(t, tt.undoneBy, entries[i].UndoneBy)
+		assert.Equal(t, tt.isUndo, entries[i].IsUndo())
+	}
+
+	assert.Equal(t, map[string]any{"System.Title": "a"}, entries[0].Changes[0].Fields)
+	assert.True(t, entries[1].Changes[0].Destroyed)
+}
+
+func Test_Entries_NoJournal(t *testing.T) {
+	setJournalFile(t, "")
+
+	entries, err := Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_Entries_Invalid(t *testing.T) {
+	setJournalFile(t, "not json\n")
+
+	_, err := Entries()
+	assert.Error(t, err)
+}
+
+func Test_Add(t *testing.T) {
+	setJournalFile(t, `{"entry":4,"command":"set 5","time":"2026-10-01T10:00:00Z","operation":"update","workItemId":5}
+`)
+
+	Add(Change{Operation: OperationUpdate, WorkItemID: 1})
+	Add(Change{Operation: OperationDelete, WorkItemID: 2})
+	// next run of a command
+	entryID = 0
+	Add(Change{Operation: OperationPage, PageID: "100", PageVersion: 3})
+
+	entries, err := Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, 5, entries[1].ID)
+	assert.Equal(t, []int{1, 2}, []int{entries[1].Changes[0].WorkItemID, entries[1].Changes[1].WorkItemID})
+	assert.Equal(t, 6, entries[2].ID)
+	assert.Equal(t, "100", entries[2].Changes[0].PageID)
+	assert.Equal(t, 3, entries[2].Changes[0].PageVersion)
+}
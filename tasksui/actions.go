@@ -0,0 +1,88 @@
+package tasksui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/samber/lo"
+)
+
+// Action is a quick action applied to the selected list item by pressing the Key.
+type Action struct {
+	Key   rune
+	Title string
+}
+
+// ActionItem is a list item, items without actions are shown as headers.
+type ActionItem struct {
+	Text        string
+	Description string
+	Actions     []rune
+}
+
+// SelectAction shows the list of items and returns the index of the item and the key of the action chosen by user,
+// index is -1 if user canceled the selection. Enter chooses the first action available for the item.
+func SelectAction(title string, items []ActionItem, actions []Action) (int, rune, error) {
+	app := tview.NewApplication()
+	selected, action := -1, rune(0)
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(true).SetTitle(" " + title + " ")
+	for _, item := range items {
+		text := item.Text
+		if len(item.Actions) == 0 {
+			text = "[::b]" + tview.Escape(text)
+		} else {
+			text = "  " + tview.Escape(text)
+		}
+		list.AddItem(text, "    "+tview.Escape(item.Description), 0, nil)
+	}
+
+	choose := func(key rune) {
+		index := list.GetCurrentItem()
+		if index < 0 || index >= len(items) || !lo.Contains(items[index].Actions, key) {
+			return
+		}
+		selected, action = index, key
+		app.Stop()
+	}
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		if index < len(items) && len(items[index].Actions) > 0 {
+			choose(items[index].Actions[0])
+		}
+	})
+
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEscape, tcell.KeyCtrlC:
+			app.Stop()
+			return nil
+		case tcell.KeyRune:
+			if lo.ContainsBy(actions, func(a Action) bool { return a.Key == ev.Rune() }) {
+				choose(ev.Rune())
+				return nil
+			}
+		}
+		return ev
+	})
+
+	help := lo.Map(actions, func(a Action, _ int) string {
+		return fmt.Sprintf("%c - %s", a.Key, a.Title)
+	})
+	helpTextView := tview.NewTextView().SetText(" " + strings.Join(help, ", ") + ", ESC - exit")
+
+	grid := tview.NewGrid().
+		SetRows(0, 1).
+		AddItem(list, 0, 0, 1, 1, 0, 0, true).
+		AddItem(helpTextView, 1, 0, 1, 1, 0, 0, false)
+
+	err := app.SetRoot(grid, true).EnableMouse(true).Run()
+	if err != nil {
+		return -1, 0, err
+	}
+
+	return selected, action, nil
+}
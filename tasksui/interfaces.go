@@ -16,6 +16,11 @@ type Task interface {
 	SetTagsString(tags string)
 }
 
+// Annotated is implemented by tasks which have additional details to show in preview.
+type Annotated interface {
+	GetAnnotation() string
+}
+
 type Table interface {
 	GetTasks() []Task
 	SetTask(tsk Task, index int)
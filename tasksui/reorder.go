@@ -0,0 +1,18 @@
+package tasksui
+
+// ReorderTasks shows the table allowing to change the order of its tasks,
+// the table is reordered in place via SetTask.
+func ReorderTasks(table Table) (bool, error) {
+	ui := newUI([]Table{table}, withReordering())
+	defer ui.app.Stop()
+
+	ui.draw()
+
+	resultChan := make(chan error)
+	go func() {
+		resultChan <- ui.app.SetRoot(ui.pages, true).EnableMouse(true).Run()
+	}()
+
+	err := <-resultChan
+	return ui.approved, err
+}
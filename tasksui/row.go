@@ -25,9 +25,14 @@ func (r *uiRow) draw() {
 		tfsTaskID = fmt.Sprintf("%d", r.task.GetTfsTaskID())
 	}
 
+	description := r.task.GetDescription()
+	if annotated, ok := r.task.(Annotated); ok {
+		description = annotated.GetAnnotation() + " " + description
+	}
+
 	r.table.SetCell(r.rowNumber, 0, tview.NewTableCell(fmt.Sprintf("%d", r.rowNumber)).SetTextColor(tcell.ColorDimGray))
 	r.table.SetCell(r.rowNumber, 1, tview.NewTableCell(cutString(r.task.GetTitle(), r.titleWidth, true)))
-	r.table.SetCell(r.rowNumber, 2, tview.NewTableCell(cutString(r.task.GetDescription(), r.descriptionWidth, true)))
+	r.table.SetCell(r.rowNumber, 2, tview.NewTableCell(cutString(description, r.descriptionWidth, true)))
 	r.table.SetCell(r.rowNumber, 3, tview.NewTableCell(fmt.Sprintf("%v", r.task.GetEstimate())))
 	r.table.SetCell(r.rowNumber, 4, tview.NewTableCell(tfsTaskID))
 }
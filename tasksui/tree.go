@@ -0,0 +1,345 @@
+package tasksui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pterm/pterm"
+	"github.com/rivo/tview"
+)
+
+// TreeItem is a work item shown in the tree.
+type TreeItem struct {
+	ID         int
+	Type       string
+	Title      string
+	State      string
+	AssignedTo string
+	Remaining  float32
+	// Total is the remaining work of the item and its whole subtree
+	Total       float32
+	HasChildren bool
+}
+
+// TreeSource loads children of tree items and applies changes made in the tree,
+// methods changing the item update its fields except Total.
+type TreeSource interface {
+	GetChildren(parent *TreeItem) ([]*TreeItem, error)
+	Update(item *TreeItem, title string, remaining float32) error
+	GetStates(item *TreeItem) ([]string, error)
+	SetState(item *TreeItem, state string) error
+	SetParent(item, parent *TreeItem) error
+	Open(item *TreeItem) error
+}
+
+type treeNode struct {
+	item   *TreeItem
+	loaded bool
+}
+
+type treeUI struct {
+	*ui
+	source TreeSource
+	view   *tview.TreeView
+	status *tview.TextView
+	// cut is the node moved by the next paste
+	cut *tview.TreeNode
+}
+
+// BrowseTree shows the tree of work items starting from the root, children are loaded on expanding.
+func BrowseTree(root *TreeItem, source TreeSource) error {
+	t := &treeUI{
+		ui: &ui{
+			app:   tview.NewApplication(),
+			pages: tview.NewPages(),
+		},
+		source: source,
+		view:   tview.NewTreeView(),
+		status: tview.NewTextView().SetDynamicColors(true),
+	}
+
+	rootNode := t.newNode(root)
+	t.view.SetRoot(rootNode).SetCurrentNode(rootNode)
+	t.view.SetBorder(true).SetTitle(fmt.Sprintf(" %d %s ", root.ID, root.Title))
+	t.view.SetSelectedFunc(t.toggle)
+	t.view.SetInputCapture(t.handleKey)
+	t.toggle(rootNode)
+
+	help := " Enter - expand/collapse, e - edit, s - state, x - cut, p - paste, o - open in browser, ESC - exit"
+	grid := tview.NewGrid().
+		SetRows(0, 1, 1).
+		AddItem(t.view, 0, 0, 1, 1, 0, 0, true).
+		AddItem(t.status, 1, 0, 1, 1, 0, 0, false).
+		AddItem(tview.NewTextView().SetText(help), 2, 0, 1, 1, 0, 0, false)
+	t.pages.AddPage("main", grid, true, true)
+
+	return t.app.SetRoot(t.pages, true).EnableMouse(true).Run()
+}
+
+func (t *treeUI) newNode(item *TreeItem) *tview.TreeNode {
+	node := tview.NewTreeNode("").SetReference(&treeNode{item: item}).SetSelectable(true)
+	t.drawNode(node)
+	return node
+}
+
+func getTreeNode(node *tview.TreeNode) *treeNode {
+	return node.GetReference().(*treeNode)
+}
+
+func (t *treeUI) drawNode(node *tview.TreeNode) {
+	item := getTreeNode(node).item
+
+	marker := " "
+	if item.HasChildren {
+		marker = "+"
+		if node.IsExpanded() && len(node.GetChildren()) > 0 {
+			marker = "-"
+		}
+	}
+
+	text := fmt.Sprintf("%s %s %d %s [gray](%s", marker, item.Type, item.ID, tview.Escape(item.Title), item.State)
+	if item.AssignedTo != "" {
+		text += ", " + tview.Escape(item.AssignedTo)
+	}
+	text += fmt.Sprintf(", remaining %gh", item.Remaining)
+	if item.HasChildren {
+		text += fmt.Sprintf(", total %gh", item.Total)
+	}
+	text += ")[-]"
+	if node == t.cut {
+		text = "[yellow]✂[-] " + text
+	}
+
+	node.SetText(text)
+}
+
+func (t *treeUI) setStatus(err error, message string) {
+	if err != nil {
+		t.status.SetText("[red]" + tview.Escape(err.Error()))
+		return
+	}
+	t.status.SetText(tview.Escape(message))
+}
+
+func (t *treeUI) toggle(node *tview.TreeNode) {
+	n := getTreeNode(node)
+	if !n.item.HasChildren {
+		return
+	}
+
+	if !n.loaded {
+		children, err := t.source.GetChildren(n.item)
+		if err != nil {
+			t.setStatus(err, "")
+			return
+		}
+
+		for _, child := range children {
+			node.AddChild(t.newNode(child))
+		}
+		n.loaded = true
+		node.SetExpanded(true)
+	} else {
+		node.SetExpanded(!node.IsExpanded())
+	}
+
+	t.drawNode(node)
+}
+
+func (t *treeUI) handleKey(ev *tcell.EventKey) *tcell.EventKey {
+	node := t.view.GetCurrentNode()
+
+	switch {
+	case ev.Key() == tcell.KeyEsc:
+		if t.cut != nil {
+			cut := t.cut
+			t.cut = nil
+			t.drawNode(cut)
+			t.setStatus(nil, "")
+		} else {
+			t.app.Stop()
+		}
+		return nil
+	case ev.Key() != tcell.KeyRune || node == nil:
+		return ev
+	}
+
+	switch ev.Rune() {
+	case 'e':
+		t.edit(node)
+	case 's':
+		t.selectState(node)
+	case 'x':
+		t.cutNode(node)
+	case 'p':
+		t.paste(node)
+	case 'o':
+		t.setStatus(t.source.Open(getTreeNode(node).item), "")
+	default:
+		return ev
+	}
+
+	return nil
+}
+
+// addTotal adds the remaining work delta to totals of the node and its ancestors.
+func (t *treeUI) addTotal(node *tview.TreeNode, delta float32) {
+	for _, n := range t.view.GetPath(node) {
+		getTreeNode(n).item.Total += delta
+		t.drawNode(n)
+	}
+}
+
+func (t *treeUI) edit(node *tview.TreeNode) {
+	item := getTreeNode(node).item
+	title, remaining := item.Title, item.Remaining
+
+	form := tview.NewForm()
+	form.SetBorder(true)
+	form.SetTitle(fmt.Sprintf(" %d ", item.ID))
+
+	form.AddInputField("Title", title, pterm.GetTerminalWidth()/2,
+		func(textToCheck string, lastChar rune) bool {
+			return len(textToCheck) > 0
+		},
+		func(text string) {
+			title = text
+		})
+
+	form.AddInputField("Remaining", fmt.Sprintf("%g", remaining), 10,
+		func(textToCheck string, lastChar rune) bool {
+			value, err := strconv.ParseFloat(textToCheck, 32)
+			return err == nil && value >= 0
+		},
+		func(text string) {
+			value, _ := strconv.ParseFloat(text, 32)
+			remaining = float32(value)
+		})
+
+	saveCb := func() {
+		t.closeModal()
+		if title == item.Title && remaining == item.Remaining {
+			return
+		}
+
+		previous := item.Remaining
+		err := t.source.Update(item, title, remaining)
+		t.addTotal(node, item.Remaining-previous)
+		t.setStatus(err, fmt.Sprintf("%d updated", item.ID))
+	}
+
+	form.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEsc:
+			t.closeModal()
+			return nil
+		case tcell.KeyCtrlS:
+			saveCb()
+			return nil
+		}
+		return ev
+	})
+
+	form.AddButton("Save", saveCb)
+	form.AddButton("Cancel", t.closeModal)
+
+	t.openModal(form)
+}
+
+func (t *treeUI) selectState(node *tview.TreeNode) {
+	item := getTreeNode(node).item
+
+	states, err := t.source.GetStates(item)
+	if err != nil || len(states) == 0 {
+		t.setStatus(err, fmt.Sprintf("no transitions from %s", item.State))
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" %d: %s → ", item.ID, item.State))
+	for _, state := range states {
+		list.AddItem(state, "", 0, nil)
+	}
+
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		t.closeModal()
+
+		previous := item.Remaining
+		err := t.source.SetState(item, states[index])
+		t.addTotal(node, item.Remaining-previous)
+		t.setStatus(err, fmt.Sprintf("%d moved to %s", item.ID, item.State))
+	})
+
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEsc {
+			t.closeModal()
+			return nil
+		}
+		return ev
+	})
+
+	t.openModal(list)
+}
+
+func (t *treeUI) cutNode(node *tview.TreeNode) {
+	if node == t.view.GetRoot() {
+		t.setStatus(errors.New("root of the tree can't be moved"), "")
+		return
+	}
+
+	previous := t.cut
+	t.cut = node
+	if previous != nil {
+		t.drawNode(previous)
+	}
+	t.drawNode(node)
+	t.setStatus(nil, fmt.Sprintf("%d cut, select new parent and press p", getTreeNode(node).item.ID))
+}
+
+func (t *treeUI) paste(target *tview.TreeNode) {
+	if t.cut == nil {
+		return
+	}
+
+	cut := t.cut
+	path := t.view.GetPath(target)
+	if slices.Contains(path, cut) {
+		t.setStatus(errors.New("work item can't be moved under itself"), "")
+		return
+	}
+
+	cutPath := t.view.GetPath(cut)
+	oldParent := cutPath[len(cutPath)-2]
+	if oldParent == target {
+		return
+	}
+
+	item, parent := getTreeNode(cut).item, getTreeNode(target).item
+	err := t.source.SetParent(item, parent)
+	if err != nil {
+		t.setStatus(err, "")
+		return
+	}
+
+	t.cut = nil
+	t.addTotal(oldParent, -item.Total)
+	oldParent.RemoveChild(cut)
+	oldItem := getTreeNode(oldParent).item
+	oldItem.HasChildren = len(oldParent.GetChildren()) > 0
+	t.drawNode(oldParent)
+
+	parent.HasChildren = true
+	t.addTotal(target, item.Total)
+	if getTreeNode(target).loaded {
+		target.AddChild(cut)
+		target.SetExpanded(true)
+		t.drawNode(cut)
+		t.view.SetCurrentNode(cut)
+	}
+	t.drawNode(target)
+
+	t.setStatus(nil, fmt.Sprintf("%d moved under %d", item.ID, parent.ID))
+}
@@ -20,6 +20,18 @@ type ui struct {
 	totalInfo        *tview.TextView
 	tables           []*uiTable
 	approved         bool
+	reorderable      bool
+	saveButtonTitle  string
+}
+
+type uiOption func(u *ui)
+
+// withReordering allows to move table rows instead of editing tasks.
+func withReordering() uiOption {
+	return func(u *ui) {
+		u.reorderable = true
+		u.saveButtonTitle = "Save"
+	}
 }
 
 func (u *ui) draw() {
@@ -61,10 +73,15 @@ func PreviewTasks(tables []Table) (bool, error) {
 	return ui.approved, <-resultChan
 }
 
-func newUI(tables []Table) *ui {
+func newUI(tables []Table, opts ...uiOption) *ui {
 	u := ui{
-		app:   tview.NewApplication(),
-		pages: tview.NewPages(),
+		app:             tview.NewApplication(),
+		pages:           tview.NewPages(),
+		saveButtonTitle: "Create",
+	}
+
+	for _, opt := range opts {
+		opt(&u)
 	}
 
 	onCancel := func() {
@@ -119,7 +136,7 @@ func newUI(tables []Table) *ui {
 	grid.SetBorders(true)
 	topGrid.AddItem(grid, 0, 0, 1, 1, 0, 0, false)
 
-	createBtn := tview.NewButton("Create")
+	createBtn := tview.NewButton(u.saveButtonTitle)
 	createBtn.SetSelectedFunc(onSave)
 	u.tabbedItems = append(u.tabbedItems, createBtn)
 
@@ -138,7 +155,11 @@ func newUI(tables []Table) *ui {
 		AddItem(nil, 0, 1, false)
 	topGrid.AddItem(btnsFlex, 1, 0, 1, 1, 0, 0, false)
 
-	topGrid.AddItem(tview.NewTextView().SetText(" Press Ctrl+S for save, press Ctrl+C or ESC to exit"), 2, 0, 1, 1, 0, 0, false)
+	help := " Press Ctrl+S for save, press Ctrl+C or ESC to exit"
+	if u.reorderable {
+		help = " Press Alt+Up/Alt+Down to move task," + help
+	}
+	topGrid.AddItem(tview.NewTextView().SetText(help), 2, 0, 1, 1, 0, 0, false)
 
 	gridRowNumber := 0
 	for i, table := range tables {
@@ -32,7 +32,7 @@ func (u *ui) newTable(table Table) *uiTable {
 
 	editRow := func(rowNumber int) {
 		taskIndex := rowNumber - ut.headerRows
-		if taskIndex < 0 || taskIndex >= len(tasks) {
+		if u.reorderable || taskIndex < 0 || taskIndex >= len(tasks) {
 			return
 		}
 		u.editTask(tasks[taskIndex].Clone(),
@@ -85,6 +85,28 @@ func (u *ui) newTable(table Table) *uiTable {
 			}
 
 			return act, ev
+		}).
+		SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+			if !u.reorderable || ev.Modifiers()&tcell.ModAlt == 0 {
+				return ev
+			}
+
+			row, _ := view.GetSelection()
+			taskIndex := row - ut.headerRows
+			switch ev.Key() {
+			case tcell.KeyUp:
+				if ut.moveTask(table, taskIndex, taskIndex-1) {
+					view.Select(row-1, 0)
+				}
+				return nil
+			case tcell.KeyDown:
+				if ut.moveTask(table, taskIndex, taskIndex+1) {
+					view.Select(row+1, 0)
+				}
+				return nil
+			}
+
+			return ev
 		})
 
 	u.tabbedItems = append(u.tabbedItems, view)
@@ -95,6 +117,24 @@ func (u *ui) newTable(table Table) *uiTable {
 	return &ut
 }
 
+// moveTask swaps two tasks both in the ui and in the source table.
+func (ut *uiTable) moveTask(table Table, from, to int) bool {
+	if from < 0 || to < 0 || from >= len(ut.tasks) || to >= len(ut.tasks) {
+		return false
+	}
+
+	ut.tasks[from], ut.tasks[to] = ut.tasks[to], ut.tasks[from]
+	table.SetTask(ut.tasks[from], from)
+	table.SetTask(ut.tasks[to], to)
+
+	ut.rows[from].task = ut.tasks[from]
+	ut.rows[to].task = ut.tasks[to]
+	ut.rows[from].draw()
+	ut.rows[to].draw()
+
+	return true
+}
+
 func (ut *uiTable) createRows(titleWidth, descriptionWidth int) {
 
 	var totalEstimate float32
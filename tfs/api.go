@@ -3,14 +3,17 @@ package tfs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"tasker/tfs/connection"
 	"tasker/tfs/identity"
 	"tasker/tfs/work"
 	"tasker/tfs/workitem"
+	"tasker/tfs/workitem/wiql"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
 	azurework "github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
 )
 
@@ -47,6 +50,78 @@ func (a *API) GetCurrentIteration(ctx context.Context) (*azurework.TeamSettingsI
 	return work.GetCurrentIteration(ctx, a.Conn, a.Project, a.Team)
 }
 
+// GetIteration returns the team iteration by name or path, "previous", "current" (or empty) and "next"
+// select iterations relative to the current one.
+func (a *API) GetIteration(ctx context.Context, nameOrPath string) (*azurework.TeamSettingsIteration, error) {
+	if nameOrPath == "" {
+		return a.GetCurrentIteration(ctx)
+	}
+
+	iterations, err := work.GetIterations(ctx, a.Conn, a.Project, a.Team)
+	if err != nil {
+		return nil, err
+	}
+
+	var iteration *azurework.TeamSettingsIteration
+	switch nameOrPath {
+	case "previous":
+		iteration = work.FindPreviousIteration(iterations)
+	case "current":
+		iteration = work.FindCurrentIteration(iterations)
+	case "next":
+		iteration = work.FindNextIteration(iterations)
+	default:
+		iteration = work.FindIteration(iterations, nameOrPath)
+	}
+
+	if iteration == nil {
+		return nil, fmt.Errorf("iteration %s not found", nameOrPath)
+	}
+	return iteration, nil
+}
+
+// GetTeamAreaCondition returns the condition matching work items of the team areas.
+func (a *API) GetTeamAreaCondition(ctx context.Context) (wiql.Condition, error) {
+	areas, err := work.GetTeamAreas(ctx, a.Conn, a.Project, a.Team)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := lo.Map(areas, func(area azurework.TeamFieldValue, _ int) wiql.Condition {
+		if area.IncludeChildren != nil && *area.IncludeChildren {
+			return wiql.Under("System.AreaPath", *area.Value)
+		}
+		return wiql.Eq("System.AreaPath", *area.Value)
+	})
+	return wiql.Or(conditions...), nil
+}
+
+// GetMemberCapacities returns capacities of the team members in the iteration.
+func (a *API) GetMemberCapacities(ctx context.Context, iteration *azurework.TeamSettingsIteration) ([]work.MemberCapacity, error) {
+	return work.GetMemberCapacities(ctx, a.Conn, a.Project, a.Team, iteration)
+}
+
+// GetMyCurrentWorkItemIDs returns IDs of work items assigned to the current user in the current iteration.
+func (a *API) GetMyCurrentWorkItemIDs(ctx context.Context, conditions ...wiql.Condition) ([]int, error) {
+	userIdentity, err := identity.Get(ctx, a.Conn)
+	if err != nil {
+		return nil, err
+	}
+
+	iteration, err := a.GetCurrentIteration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.WiClient.QueryIDs(ctx, wiql.Select("System.Id").
+		Where(
+			wiql.Eq("System.AssignedTo", userIdentity.DisplayName),
+			wiql.Under("System.IterationPath", *iteration.Path),
+		).
+		Where(conditions...).
+		OrderBy("System.Id"))
+}
+
 func (a *API) CreateWorkItem(ctx context.Context, workitemType, title, description string, estimate float32, parentID int, relations []*workitem.Relation, tags []string, parentNamePattern string, assign, currentIter bool) (*workitemtracking.WorkItem, error) {
 	var err error
 	var parent *workitemtracking.WorkItem
@@ -94,7 +169,7 @@ func (a *API) CreateWorkItem(ctx context.Context, workitemType, title, descripti
 
 	var workitem *workitemtracking.WorkItem
 	if workitemType == "Requirement" {
-		workitem, err = a.WiClient.CreateRequirement(ctx, "Development", title, description, areaPath, iterationPath, estimate, 1, relations, tags)
+		workitem, err = a.WiClient.CreateRequirement(ctx, "Development", title, description, areaPath, iterationPath, estimate, 1, relations, tags, "")
 	} else {
 		workitem, err = a.WiClient.CreateTask(ctx, title, description, areaPath, iterationPath, estimate, relations, tags, "", "", "", "")
 	}
@@ -160,10 +235,12 @@ func (a *API) CreateChildTask(ctx context.Context, title, description string, es
 	return a.WiClient.CreateTask(ctx, title, description, areaPath, iterationPath, estimate, relations, tags, assignedTo, startDate, finishDate, priority)
 }
 
-func (a *API) CreateChildRequirement(ctx context.Context, requirementType, title, description string, estimate, priority float32, parent *workitemtracking.WorkItem, tags []string) (*workitemtracking.WorkItem, error) {
+func (a *API) CreateChildRequirement(ctx context.Context, requirementType, title, description string, estimate, priority float32, parent *workitemtracking.WorkItem, tags []string, areaPath, assignedTo string) (*workitemtracking.WorkItem, error) {
 	// iterationPath := workitem.GetIterationPath(parent)
-	areaPath := workitem.GetAreaPath(parent)
-	iterationPath := areaPath
+	iterationPath := workitem.GetAreaPath(parent)
+	if areaPath == "" {
+		areaPath = iterationPath
+	}
 	relations := []*workitem.Relation{
 		{
 			URL:  *parent.Url,
@@ -171,5 +248,5 @@ func (a *API) CreateChildRequirement(ctx context.Context, requirementType, title
 		},
 	}
 
-	return a.WiClient.CreateRequirement(ctx, requirementType, title, description, areaPath, iterationPath, estimate, priority, relations, tags)
+	return a.WiClient.CreateRequirement(ctx, requirementType, title, description, areaPath, iterationPath, estimate, priority, relations, tags, assignedTo)
 }
@@ -2,7 +2,7 @@ package identity
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"tasker/ptr"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
@@ -16,8 +16,11 @@ type Identity struct {
 }
 
 func Get(ctx context.Context, conn *azuredevops.Connection) (*Identity, error) {
-	userFilter := viper.GetString("tfsUserFilter")
+	return Find(ctx, conn, viper.GetString("tfsUserFilter"))
+}
 
+// Find returns the only identity matching the filter (account or display name, email).
+func Find(ctx context.Context, conn *azuredevops.Connection, filter string) (*Identity, error) {
 	client, err := identity.NewClient(ctx, conn)
 	if err != nil {
 		return nil, err
@@ -25,7 +28,7 @@ func Get(ctx context.Context, conn *azuredevops.Connection) (*Identity, error) {
 
 	identities, err := client.ReadIdentities(ctx, identity.ReadIdentitiesArgs{
 		SearchFilter:    ptr.FromStr("General"),
-		FilterValue:     ptr.FromStr(userFilter),
+		FilterValue:     &filter,
 		QueryMembership: &identity.QueryMembershipValues.None,
 	})
 	if err != nil {
@@ -33,11 +36,11 @@ func Get(ctx context.Context, conn *azuredevops.Connection) (*Identity, error) {
 	}
 
 	if identities == nil || len(*identities) == 0 {
-		return nil, errors.New("user identity not found")
+		return nil, fmt.Errorf("user identity '%s' not found", filter)
 	}
 
 	if len(*identities) > 1 {
-		return nil, errors.New("user filter not unique")
+		return nil, fmt.Errorf("user filter '%s' not unique", filter)
 	}
 
 	identity := (*identities)[0]
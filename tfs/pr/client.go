@@ -11,6 +11,7 @@ import (
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/google/uuid"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
 )
@@ -142,3 +143,19 @@ func getRepositoryNames(reps []git.GitRepository) []string {
 
 	return repNames
 }
+
+// GetActivePullRequestsByCreator returns active pull requests of the user across all repositories of the project.
+func (c *Client) GetActivePullRequestsByCreator(ctx context.Context, creatorID uuid.UUID) ([]git.GitPullRequest, error) {
+	prs, err := c.GetPullRequestsByProject(ctx, git.GetPullRequestsByProjectArgs{
+		Project: &c.project,
+		SearchCriteria: &git.GitPullRequestSearchCriteria{
+			CreatorId: &creatorID,
+			Status:    &git.PullRequestStatusValues.Active,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return *prs, nil
+}
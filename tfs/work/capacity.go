@@ -0,0 +1,109 @@
+package work
+
+import (
+	"context"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/work"
+)
+
+// MemberCapacity is the capacity of the team member in the iteration.
+type MemberCapacity struct {
+	DisplayName    string
+	UniqueName     string
+	CapacityPerDay float32
+	WorkingDays    int
+	// Capacity is hours available in the iteration without team and member days off.
+	Capacity float32
+}
+
+// GetMemberCapacities returns capacities of the team members in the iteration.
+func GetMemberCapacities(ctx context.Context, conn *azuredevops.Connection, project, team string, iteration *work.TeamSettingsIteration) ([]MemberCapacity, error) {
+	client, err := work.NewClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	capacities, err := client.GetCapacitiesWithIdentityRef(ctx, work.GetCapacitiesWithIdentityRefArgs{
+		Project:     &project,
+		Team:        &team,
+		IterationId: iteration.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	teamDaysOff, err := client.GetTeamDaysOff(ctx, work.GetTeamDaysOffArgs{
+		Project:     &project,
+		Team:        &team,
+		IterationId: iteration.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var daysOff []work.DateRange
+	if teamDaysOff != nil && teamDaysOff.DaysOff != nil {
+		daysOff = *teamDaysOff.DaysOff
+	}
+
+	var start, finish time.Time
+	if iteration.Attributes != nil && iteration.Attributes.StartDate != nil && iteration.Attributes.FinishDate != nil {
+		start, finish = iteration.Attributes.StartDate.Time, iteration.Attributes.FinishDate.Time
+	}
+
+	var result []MemberCapacity
+	for _, capacity := range *capacities {
+		if capacity.TeamMember == nil {
+			continue
+		}
+
+		memberCapacity := MemberCapacity{}
+		if capacity.TeamMember.DisplayName != nil {
+			memberCapacity.DisplayName = *capacity.TeamMember.DisplayName
+		}
+		if capacity.TeamMember.UniqueName != nil {
+			memberCapacity.UniqueName = *capacity.TeamMember.UniqueName
+		}
+		if capacity.Activities != nil {
+			for _, activity := range *capacity.Activities {
+				if activity.CapacityPerDay != nil {
+					memberCapacity.CapacityPerDay += *activity.CapacityPerDay
+				}
+			}
+		}
+
+		memberDaysOff := daysOff
+		if capacity.DaysOff != nil {
+			memberDaysOff = append(memberDaysOff[:len(memberDaysOff):len(memberDaysOff)], *capacity.DaysOff...)
+		}
+
+		memberCapacity.WorkingDays = CountWorkingDays(start, finish, memberDaysOff)
+		memberCapacity.Capacity = memberCapacity.CapacityPerDay * float32(memberCapacity.WorkingDays)
+		result = append(result, memberCapacity)
+	}
+
+	return result, nil
+}
+
+// CountWorkingDays returns the number of days from start to finish inclusive except weekends and days off.
+func CountWorkingDays(start, finish time.Time, daysOff []work.DateRange) int {
+	var count int
+	for date := start; !date.After(finish); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday || isDayOff(date, daysOff) {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func isDayOff(date time.Time, daysOff []work.DateRange) bool {
+	for _, dayOff := range daysOff {
+		if dayOff.Start != nil && dayOff.End != nil && !date.Before(dayOff.Start.Time) && !date.After(dayOff.End.Time) {
+			return true
+		}
+	}
+	return false
+}
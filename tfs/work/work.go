@@ -51,3 +51,43 @@ func FindPreviousIteration(iterations *[]work.TeamSettingsIteration) *work.TeamS
 	}
 	return nil
 }
+
+func FindNextIteration(iterations *[]work.TeamSettingsIteration) *work.TeamSettingsIteration {
+	for i := 0; i < len(*iterations)-1; i++ {
+		if *(*iterations)[i].Attributes.TimeFrame == "current" {
+			return &(*iterations)[i+1]
+		}
+	}
+	return nil
+}
+
+// FindIteration returns the iteration by name or path.
+func FindIteration(iterations *[]work.TeamSettingsIteration, nameOrPath string) *work.TeamSettingsIteration {
+	for i := range *iterations {
+		if *(*iterations)[i].Name == nameOrPath || *(*iterations)[i].Path == nameOrPath {
+			return &(*iterations)[i]
+		}
+	}
+	return nil
+}
+
+// GetTeamAreas returns area paths of the team.
+func GetTeamAreas(ctx context.Context, conn *azuredevops.Connection, project, team string) ([]work.TeamFieldValue, error) {
+	client, err := work.NewClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := client.GetTeamFieldValues(ctx, work.GetTeamFieldValuesArgs{
+		Project: &project,
+		Team:    &team,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if values.Values == nil || len(*values.Values) == 0 {
+		return nil, errors.New("team areas not found")
+	}
+	return *values.Values, nil
+}
@@ -0,0 +1,73 @@
+package workitem
+
+import (
+	"context"
+	"io"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+)
+
+const AttachedFileRelation = "AttachedFile"
+
+type Attachment struct {
+	ID      uuid.UUID
+	URL     string
+	Name    string
+	Size    int64
+	Comment string
+	Date    time.Time
+}
+
+// UploadAttachment uploads the file content, the attachment is linked to a work item by AttachedFile relation.
+func (api *Client) UploadAttachment(ctx context.Context, fileName string, content io.Reader) (*workitemtracking.AttachmentReference, error) {
+	return api.CreateAttachment(ctx, workitemtracking.CreateAttachmentArgs{
+		UploadStream: content,
+		Project:      &api.project,
+		FileName:     &fileName,
+	})
+}
+
+// DownloadAttachment returns content of the attachment, the caller closes it.
+func (api *Client) DownloadAttachment(ctx context.Context, attachment Attachment) (io.ReadCloser, error) {
+	download := true
+	return api.GetAttachmentContent(ctx, workitemtracking.GetAttachmentContentArgs{
+		Id:       &attachment.ID,
+		Project:  &api.project,
+		FileName: &attachment.Name,
+		Download: &download,
+	})
+}
+
+// GetAttachments returns attachments of the work item fetched with relations.
+func GetAttachments(w *workitemtracking.WorkItem) []Attachment {
+	if w.Relations == nil {
+		return nil
+	}
+
+	var attachments []Attachment
+	for _, relation := range *w.Relations {
+		if relation.Rel == nil || *relation.Rel != AttachedFileRelation || relation.Url == nil {
+			continue
+		}
+
+		attachment := Attachment{URL: *relation.Url}
+		attachment.ID, _ = uuid.Parse(path.Base(*relation.Url))
+		if relation.Attributes != nil {
+			attributes := *relation.Attributes
+			attachment.Name, _ = attributes["name"].(string)
+			attachment.Comment, _ = attributes["comment"].(string)
+			if size, ok := attributes["resourceSize"].(float64); ok {
+				attachment.Size = int64(size)
+			}
+			if date, ok := attributes["authorizedDate"].(string); ok {
+				attachment.Date, _ = time.Parse(time.RFC3339, date)
+			}
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments
+}
@@ -0,0 +1,44 @@
+package workitem
+
+import (
+	"context"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+)
+
+// AddComment posts the comment to the work item discussion, the text is HTML.
+func (api *Client) AddComment(ctx context.Context, workItemID int, text string) (*workitemtracking.Comment, error) {
+	return api.Client.AddComment(ctx, workitemtracking.AddCommentArgs{
+		Request: &workitemtracking.CommentCreate{
+			Text: &text,
+		},
+		Project:    &api.project,
+		WorkItemId: &workItemID,
+	})
+}
+
+// GetComments returns all comments of the work item discussion from the oldest one.
+func (api *Client) GetComments(ctx context.Context, workItemID int) ([]workitemtracking.Comment, error) {
+	var comments []workitemtracking.Comment
+	var continuationToken *string
+	for {
+		page, err := api.Client.GetComments(ctx, workitemtracking.GetCommentsArgs{
+			Project:           &api.project,
+			WorkItemId:        &workItemID,
+			ContinuationToken: continuationToken,
+			Order:             &workitemtracking.CommentSortOrderValues.Asc,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if page.Comments != nil {
+			comments = append(comments, *page.Comments...)
+		}
+
+		if page.ContinuationToken == nil || *page.ContinuationToken == "" {
+			return comments, nil
+		}
+		continuationToken = page.ContinuationToken
+	}
+}
@@ -0,0 +1,344 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"tasker/journal"
+	"tasker/ptr"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/samber/lo"
+)
+
+// journalStateFields are set by the server on state changes and are not reverted.
+var journalStateFields = []string{
+	"System.Reason",
+	"System.History",
+	"Microsoft.VSTS.Common.ResolvedReason",
+}
+
+// IsJournaledField reports whether previous values of the field are saved into the journal to be reverted,
+// computed and read-only fields are skipped.
+func IsJournaledField(field string) bool {
+	return isCopiedField(field, &CopyOptions{}) && !slices.Contains(journalStateFields, field)
+}
+
+type previousKey struct{}
+
+// WithPrevious returns the context carrying the work item already read by the caller with all fields, it is saved
+// into the journal as the previous state instead of reading the work item again. The work item must be read
+// with relations if the update changes them or the work item is deleted.
+func WithPrevious(ctx context.Context, w *workitemtracking.WorkItem) context.Context {
+	return context.WithValue(ctx, previousKey{}, w)
+}
+
+// getPrevious returns the previous state of the work item from the context or reads it,
+// relations are read only if required.
+func (api *Client) getPrevious(ctx context.Context, workItemID int, withRelations bool) (*workitemtracking.WorkItem, error) {
+	if previous, ok := ctx.Value(previousKey{}).(*workitemtracking.WorkItem); ok && previous != nil && *previous.Id == workItemID {
+		return previous, nil
+	}
+
+	if withRelations {
+		return api.GetWithRelations(ctx, workItemID)
+	}
+	return api.Get(ctx, workItemID)
+}
+
+// UpdateWorkItem updates the work item saving previous values of changed fields and relations into the journal.
+func (api *Client) UpdateWorkItem(ctx context.Context, args workitemtracking.UpdateWorkItemArgs) (*workitemtracking.WorkItem, error) {
+	relationsChanged := hasRelationsOperations(args.Document)
+	previous, err := api.getPrevious(ctx, *args.Id, relationsChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	if relationsChanged && args.Expand == nil {
+		args.Expand = &workitemtracking.WorkItemExpandValues.Relations
+	}
+
+	wi, err := api.Client.UpdateWorkItem(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	change := journal.Change{
+		Operation:  journal.OperationUpdate,
+		WorkItemID: *wi.Id,
+		Rev:        *wi.Rev,
+		Fields:     getChangedFields(previous, wi),
+	}
+	if relationsChanged && !reflect.DeepEqual(getJournalRelations(previous), getJournalRelations(wi)) {
+		relations := getJournalRelations(previous)
+		change.Relations = &relations
+	}
+
+	if len(change.Fields) > 0 || change.Relations != nil {
+		journal.Add(change)
+	}
+	return wi, nil
+}
+
+func hasRelationsOperations(document *[]webapi.JsonPatchOperation) bool {
+	if document == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(*document, func(operation webapi.JsonPatchOperation) bool {
+		return operation.Path != nil && strings.HasPrefix(*operation.Path, "/relations")
+	})
+}
+
+// CreateWorkItem creates the work item saving it into the journal.
+func (api *Client) CreateWorkItem(ctx context.Context, args workitemtracking.CreateWorkItemArgs) (*workitemtracking.WorkItem, error) {
+	wi, err := api.Client.CreateWorkItem(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	journal.Add(journal.Change{
+		Operation:  journal.OperationCreate,
+		WorkItemID: *wi.Id,
+		Rev:        *wi.Rev,
+	})
+	return wi, nil
+}
+
+// DeleteWorkItem deletes the work item saving its fields and relations into the journal.
+func (api *Client) DeleteWorkItem(ctx context.Context, args workitemtracking.DeleteWorkItemArgs) (*workitemtracking.WorkItemDelete, error) {
+	previous, err := api.getPrevious(ctx, *args.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := api.Client.DeleteWorkItem(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	relations := getJournalRelations(previous)
+	journal.Add(journal.Change{
+		Operation:  journal.OperationDelete,
+		WorkItemID: *previous.Id,
+		Rev:        *previous.Rev,
+		Type:       GetType(previous),
+		Fields:     *previous.Fields,
+		Relations:  &relations,
+		Destroyed:  args.Destroy != nil && *args.Destroy,
+	})
+	return result, nil
+}
+
+// RestoreWorkItem restores the work item from the recycle bin saving it into the journal.
+func (api *Client) RestoreWorkItem(ctx context.Context, args workitemtracking.RestoreWorkItemArgs) (*workitemtracking.WorkItemDelete, error) {
+	result, err := api.Client.RestoreWorkItem(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	journal.Add(journal.Change{
+		Operation:  journal.OperationRestore,
+		WorkItemID: *args.Id,
+	})
+	return result, nil
+}
+
+// GetWithRelations returns the work item with all fields and relations.
+func (api *Client) GetWithRelations(ctx context.Context, workItemID int) (*workitemtracking.WorkItem, error) {
+	return api.GetWorkItem(ctx, workitemtracking.GetWorkItemArgs{
+		Id:      &workItemID,
+		Project: &api.project,
+		Expand:  &workitemtracking.WorkItemExpandValues.Relations,
+	})
+}
+
+func getChangedFields(previous, current *workitemtracking.WorkItem) map[string]any {
+	previousFields, currentFields := map[string]any{}, map[string]any{}
+	if previous.Fields != nil {
+		previousFields = *previous.Fields
+	}
+	if current.Fields != nil {
+		currentFields = *current.Fields
+	}
+
+	changed := make(map[string]any)
+	for field := range maps.Keys(previousFields) {
+		if IsJournaledField(field) && !reflect.DeepEqual(previousFields[field], currentFields[field]) {
+			changed[field] = previousFields[field]
+		}
+	}
+	for field := range maps.Keys(currentFields) {
+		if _, ok := previousFields[field]; !ok && IsJournaledField(field) {
+			changed[field] = nil
+		}
+	}
+
+	return changed
+}
+
+func getJournalRelations(w *workitemtracking.WorkItem) []journal.Relation {
+	relations := make([]journal.Relation, 0)
+	if w.Relations == nil {
+		return relations
+	}
+
+	for _, relation := range *w.Relations {
+		r := journal.Relation{Rel: *relation.Rel, URL: *relation.Url}
+		if relation.Attributes != nil {
+			r.Attributes = *relation.Attributes
+		}
+		relations = append(relations, r)
+	}
+
+	// order of relations is not significant
+	slices.SortFunc(relations, func(a, b journal.Relation) int {
+		return strings.Compare(a.Rel+a.URL, b.Rel+b.URL)
+	})
+	return relations
+}
+
+// Revert sets the fields to the previous values (nil value removes the field) and replaces relations
+// by the previous ones if specified. The previous state is reached passing intermediate states if required.
+func (api *Client) Revert(ctx context.Context, current *workitemtracking.WorkItem, fields map[string]any, relations *[]journal.Relation) (*workitemtracking.WorkItem, error) {
+	workItemID := *current.Id
+	currentFields := map[string]any{}
+	if current.Fields != nil {
+		currentFields = *current.Fields
+	}
+
+	var operations []webapi.JsonPatchOperation
+	if relations != nil {
+		operations = append(operations, getRevertRelationsOperations(current, *relations)...)
+	}
+
+	// the current work item is the previous state of the update unless it is moved through intermediate states
+	updateCtx := WithPrevious(ctx, current)
+	if state, ok := fields["System.State"].(string); ok && state != GetState(current) {
+		transitions, err := api.GetTypeTransitions(ctx, GetType(current))
+		if err != nil {
+			return nil, err
+		}
+
+		path := FindTransitionPath(transitions, GetState(current), state)
+		if path == nil {
+			return nil, fmt.Errorf("no transitions from %s to %s for %s", GetState(current), state, GetType(current))
+		}
+
+		previous := current
+		for _, step := range path[:len(path)-1] {
+			previous, err = api.Transition(WithPrevious(ctx, previous), workItemID, step, nil)
+			if err != nil {
+				return nil, err
+			}
+			updateCtx = ctx
+		}
+	}
+
+	readOnlyFields, err := api.GetReadOnlyFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		if !IsJournaledField(field) || readOnlyFields[field] {
+			continue
+		}
+
+		value := getCopiedFieldValue(fields[field])
+		if value == nil {
+			if _, ok := currentFields[field]; ok {
+				operations = append(operations, webapi.JsonPatchOperation{
+					Op:   &webapi.OperationValues.Remove,
+					Path: ptr.FromStr("/fields/" + field),
+				})
+			}
+			continue
+		}
+
+		operations = append(operations, webapi.JsonPatchOperation{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/" + field),
+			Value: value,
+		})
+	}
+
+	if len(operations) == 0 {
+		return current, nil
+	}
+
+	return api.UpdateWorkItem(updateCtx, workitemtracking.UpdateWorkItemArgs{
+		Id:       &workItemID,
+		Project:  &api.project,
+		Document: &operations,
+	})
+}
+
+// getRevertRelationsOperations removes relations missing in the previous ones and adds the previous ones
+// missing in the current relations.
+func getRevertRelationsOperations(current *workitemtracking.WorkItem, previous []journal.Relation) []webapi.JsonPatchOperation {
+	key := func(rel, url string) string {
+		return rel + " " + strings.ToLower(url)
+	}
+
+	var operations []webapi.JsonPatchOperation
+	currentKeys := make(map[string]bool)
+	if current.Relations != nil {
+		previousKeys := lo.SliceToMap(previous, func(relation journal.Relation) (string, bool) {
+			return key(relation.Rel, relation.URL), true
+		})
+
+		// relations are removed from the end to keep the rest indexes valid
+		for i := len(*current.Relations) - 1; i >= 0; i-- {
+			relation := (*current.Relations)[i]
+			currentKeys[key(*relation.Rel, *relation.Url)] = true
+			if !previousKeys[key(*relation.Rel, *relation.Url)] {
+				operations = append(operations, webapi.JsonPatchOperation{
+					Op:   &webapi.OperationValues.Remove,
+					Path: ptr.FromStr(fmt.Sprintf("/relations/%d", i)),
+				})
+			}
+		}
+	}
+
+	for _, relation := range previous {
+		if !currentKeys[key(relation.Rel, relation.URL)] {
+			operations = append(operations, getJournalRelation(relation).operation())
+		}
+	}
+
+	return operations
+}
+
+func getJournalRelation(relation journal.Relation) *Relation {
+	comment, _ := relation.Attributes["comment"].(string)
+	return &Relation{URL: relation.URL, Type: relation.Rel, Comment: comment}
+}
+
+// recreatedSkippedRelationTypes are not restored on recreation: attachments are destroyed with the work item,
+// the parent and children could be changed or deleted since.
+var recreatedSkippedRelationTypes = []string{
+	"AttachedFile",
+	"System.LinkTypes.Hierarchy-Forward",
+	"System.LinkTypes.Hierarchy-Reverse",
+}
+
+// Recreate creates a work item with the writable fields and relations of the deleted one,
+// attachments and hierarchy links are not restored.
+func (api *Client) Recreate(ctx context.Context, fields map[string]any, relations []journal.Relation) (*workitemtracking.WorkItem, error) {
+	source := &workitemtracking.WorkItem{Fields: &fields}
+	relations = slices.DeleteFunc(slices.Clone(relations), func(relation journal.Relation) bool {
+		return slices.Contains(recreatedSkippedRelationTypes, relation.Rel)
+	})
+
+	return api.Copy(ctx, source, GetAreaPath(source), GetIterationPath(source),
+		lo.Map(relations, func(relation journal.Relation, _ int) *Relation {
+			return getJournalRelation(relation)
+		}),
+		GetTags(source))
+}
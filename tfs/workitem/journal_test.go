@@ -0,0 +1,181 @@
+package workitem
+
+import (
+	"context"
+	"testing"
+
+	"tasker/journal"
+	"tasker/ptr"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getChangedFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous map[string]any
+		current  map[string]any
+		want     map[string]any
+	}{
+		{
+			name:     "changed",
+			previous: map[string]any{"System.Title": "a", "System.State": "New"},
+			current:  map[string]any{"System.Title": "b", "System.State": "New"},
+			want:     map[string]any{"System.Title": "a"},
+		},
+		{
+			name:     "added",
+			previous: map[string]any{"System.Title": "a"},
+			current:  map[string]any{"System.Title": "a", "Microsoft.VSTS.Scheduling.RemainingWork": 5.0},
+			want:     map[string]any{"Microsoft.VSTS.Scheduling.RemainingWork": nil},
+		},
+		{
+			name:     "removed",
+			previous: map[string]any{"System.Title": "a", "System.Tags": "x"},
+			current:  map[string]any{"System.Title": "a"},
+			want:     map[string]any{"System.Tags": "x"},
+		},
+		{
+			name:     "identity",
+			previous: map[string]any{"System.AssignedTo": map[string]any{"uniqueName": "a@b.c"}},
+			current:  map[string]any{"System.AssignedTo": map[string]any{"uniqueName": "d@b.c"}},
+			want:     map[string]any{"System.AssignedTo": map[string]any{"uniqueName": "a@b.c"}},
+		},
+		{
+			name: "computed and state fields skipped",
+			previous: map[string]any{
+				"System.Rev":                       1,
+				"System.RelatedLinkCount":          0,
+				"System.AttachedFileCount":         0,
+				"System.Reason":                    "New",
+				"Microsoft.VSTS.Common.ClosedDate": nil,
+			},
+			current: map[string]any{
+				"System.Rev":                       2,
+				"System.RelatedLinkCount":          1,
+				"System.AttachedFileCount":         1,
+				"System.Reason":                    "Done",
+				"System.History":                   "comment",
+				"Microsoft.VSTS.Common.ClosedDate": "2026-10-01T10:00:00Z",
+			},
+			want: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getChangedFields(
+				&workitemtracking.WorkItem{Fields: &tt.previous},
+				&workitemtracking.WorkItem{Fields: &tt.current})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getJournalRelations(t *testing.T) {
+	wi := &workitemtracking.WorkItem{Relations: &[]workitemtracking.WorkItemRelation{
+		{Rel: ptr.FromStr("System.LinkTypes.Related"), Url: ptr.FromStr("https://tfs/_apis/wit/workItems/2")},
+		{
+			Rel:        ptr.FromStr("System.LinkTypes.Hierarchy-Reverse"),
+			Url:        ptr.FromStr("https://tfs/_apis/wit/workItems/1"),
+			Attributes: &map[string]any{"comment": "parent"},
+		},
+	}}
+
+	assert.Equal(t, []journal.Relation{
+		{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://tfs/_apis/wit/workItems/1", Attributes: map[string]any{"comment": "parent"}},
+		{Rel: "System.LinkTypes.Related", URL: "https://tfs/_apis/wit/workItems/2"},
+	}, getJournalRelations(wi))
+
+	assert.Equal(t, []journal.Relation{}, getJournalRelations(&workitemtracking.WorkItem{}))
+}
+
+func Test_getRevertRelationsOperations(t *testing.T) {
+	relation := func(rel, url string) workitemtracking.WorkItemRelation {
+		return workitemtracking.WorkItemRelation{Rel: &rel, Url: &url}
+	}
+
+	tests := []struct {
+		name     string
+		current  []workitemtracking.WorkItemRelation
+		previous []journal.Relation
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			current:  []workitemtracking.WorkItemRelation{relation("Related", "https://tfs/workItems/1")},
+			previous: []journal.Relation{{Rel: "Related", URL: "https://tfs/workItems/1"}},
+		},
+		{
+			name: "removed from the end",
+			current: []workitemtracking.WorkItemRelation{
+				relation("Related", "https://tfs/workItems/1"),
+				relation("Related", "https://tfs/workItems/2"),
+				relation("Related", "https://tfs/workItems/3"),
+			},
+			previous: []journal.Relation{{Rel: "Related", URL: "https://tfs/workItems/2"}},
+			want:     []string{"remove /relations/2", "remove /relations/0"},
+		},
+		{
+			name: "parent replaced",
+			current: []workitemtracking.WorkItemRelation{
+				relation("System.LinkTypes.Hierarchy-Reverse", "https://tfs/workItems/10"),
+				relation("Related", "https://tfs/workItems/1"),
+			},
+			previous: []journal.Relation{
+				{Rel: "Related", URL: "https://tfs/workItems/1"},
+				{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://tfs/workItems/20"},
+			},
+			want: []string{"remove /relations/0", "add /relations/- System.LinkTypes.Hierarchy-Reverse https://tfs/workItems/20"},
+		},
+		{
+			name:     "URL case ignored",
+			current:  []workitemtracking.WorkItemRelation{relation("Related", "https://TFS/workItems/1")},
+			previous: []journal.Relation{{Rel: "Related", URL: "https://tfs/workItems/1"}},
+		},
+		{
+			name:     "no current relations",
+			previous: []journal.Relation{{Rel: "Related", URL: "https://tfs/workItems/1"}},
+			want:     []string{"add /relations/- Related https://tfs/workItems/1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &workitemtracking.WorkItem{}
+			if tt.current != nil {
+				current.Relations = &tt.current
+			}
+
+			var got []string
+			for _, operation := range getRevertRelationsOperations(current, tt.previous) {
+				s := string(*operation.Op) + " " + *operation.Path
+				if value, ok := operation.Value.(workitemtracking.WorkItemRelation); ok {
+					s += " " + *value.Rel + " " + *value.Url
+				}
+				got = append(got, s)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_hasRelationsOperations(t *testing.T) {
+	fieldOperation := webapi.JsonPatchOperation{Op: &webapi.OperationValues.Add, Path: ptr.FromStr("/fields/System.Title")}
+	relationOperation := webapi.JsonPatchOperation{Op: &webapi.OperationValues.Remove, Path: ptr.FromStr("/relations/2")}
+
+	assert.False(t, hasRelationsOperations(nil))
+	assert.False(t, hasRelationsOperations(&[]webapi.JsonPatchOperation{fieldOperation}))
+	assert.True(t, hasRelationsOperations(&[]webapi.JsonPatchOperation{fieldOperation, relationOperation}))
+}
+
+func Test_getPrevious_FromContext(t *testing.T) {
+	previous := &workitemtracking.WorkItem{Id: ptr.FromInt(42), Rev: ptr.FromInt(3)}
+	api := &Client{}
+
+	got, err := api.getPrevious(WithPrevious(context.Background(), previous), 42, true)
+	assert.NoError(t, err)
+	assert.Same(t, previous, got)
+}
@@ -0,0 +1,112 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tasker/ptr"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/samber/lo"
+)
+
+// LinkTypes maps friendly names of link types to reference names,
+// the name tells what the linked work item is for the work item having the link.
+var LinkTypes = map[string]string{
+	"related":      "System.LinkTypes.Related",
+	"parent":       "System.LinkTypes.Hierarchy-Reverse",
+	"child":        "System.LinkTypes.Hierarchy-Forward",
+	"predecessor":  "System.LinkTypes.Dependency-Reverse",
+	"successor":    "System.LinkTypes.Dependency-Forward",
+	"duplicate":    "System.LinkTypes.Duplicate-Forward",
+	"duplicate-of": "System.LinkTypes.Duplicate-Reverse",
+	"affects":      "Microsoft.VSTS.Common.Affects-Forward",
+	"affected-by":  "Microsoft.VSTS.Common.Affects-Reverse",
+}
+
+// GetLinkType returns the reference name of the link type by friendly or reference name.
+func GetLinkType(name string) (string, error) {
+	if linkType, ok := LinkTypes[strings.ToLower(name)]; ok {
+		return linkType, nil
+	}
+	if slices.Contains(lo.Values(LinkTypes), name) {
+		return name, nil
+	}
+
+	names := lo.Keys(LinkTypes)
+	slices.Sort(names)
+	return "", fmt.Errorf("unknown link type '%s', expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// GetLinkTypeName returns the friendly name of the link type, the reference name if it has no friendly one.
+func GetLinkTypeName(linkType string) string {
+	name, ok := lo.FindKey(LinkTypes, linkType)
+	if !ok {
+		return linkType
+	}
+	return name
+}
+
+// GetRelationWorkItemID returns ID of the work item linked by the relation, false for other relations.
+func GetRelationWorkItemID(relation workitemtracking.WorkItemRelation) (int, bool) {
+	if relation.Url == nil {
+		return 0, false
+	}
+
+	url := *relation.Url
+	i := strings.LastIndex(strings.ToLower(url), "/workitems/")
+	if i < 0 {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(url[i+len("/workitems/"):])
+	return id, err == nil
+}
+
+// FindLinks returns indexes of relations of the type (any work item link if empty) to the linked work item.
+func FindLinks(w *workitemtracking.WorkItem, linkType string, linkedID int) []int {
+	if w.Relations == nil {
+		return nil
+	}
+
+	var result []int
+	for i, relation := range *w.Relations {
+		id, ok := GetRelationWorkItemID(relation)
+		if ok && id == linkedID && (linkType == "" || *relation.Rel == linkType) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// RemoveRelations removes relations by indexes if the work item is still at the revision.
+func (api *Client) RemoveRelations(ctx context.Context, workItemID, rev int, indexes ...int) (*workitemtracking.WorkItem, error) {
+	operations := []webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Test,
+			Path:  ptr.FromStr("/rev"),
+			Value: rev,
+		},
+	}
+
+	// relations are removed from the end to keep the rest indexes valid
+	indexes = slices.Clone(indexes)
+	slices.Sort(indexes)
+	slices.Reverse(indexes)
+	for _, index := range indexes {
+		operations = append(operations, webapi.JsonPatchOperation{
+			Op:   &webapi.OperationValues.Remove,
+			Path: ptr.FromStr(fmt.Sprintf("/relations/%d", index)),
+		})
+	}
+
+	return api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(workItemID),
+		Project:  &api.project,
+		Document: &operations,
+	})
+}
@@ -0,0 +1,121 @@
+package workitem
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"tasker/ptr"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+)
+
+const revisionsPageSize = 200
+
+// GetRevisions returns all revisions of the work item from the oldest one.
+func (api *Client) GetRevisions(ctx context.Context, workItemID int) ([]workitemtracking.WorkItem, error) {
+	var revisions []workitemtracking.WorkItem
+	for {
+		page, err := api.Client.GetRevisions(ctx, workitemtracking.GetRevisionsArgs{
+			Id:      ptr.FromInt(workItemID),
+			Project: &api.project,
+			Top:     ptr.FromInt(revisionsPageSize),
+			Skip:    ptr.FromInt(len(revisions)),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if page == nil {
+			return revisions, nil
+		}
+
+		revisions = append(revisions, *page...)
+		if len(*page) < revisionsPageSize {
+			return revisions, nil
+		}
+	}
+}
+
+// revisionServiceFields change with every revision and are not reported as changes.
+var revisionServiceFields = []string{
+	"System.Rev",
+	"System.Watermark",
+	"System.ChangedDate",
+	"System.ChangedBy",
+	"System.AuthorizedDate",
+	"System.AuthorizedAs",
+	"System.RevisedDate",
+	"System.PersonId",
+	"System.AreaId",
+	"System.IterationId",
+	"System.NodeName",
+	"System.CommentCount",
+}
+
+type FieldChange struct {
+	Field    string
+	OldValue any
+	NewValue any
+}
+
+// RevisionChanges are field changes made in the revision.
+type RevisionChanges struct {
+	Rev         int
+	ChangedBy   any
+	ChangedDate time.Time
+	Changes     []FieldChange
+}
+
+// GetRevisionsChanges compares each revision with the previous one, the first revision changes
+// all fields from empty values.
+func GetRevisionsChanges(revisions []workitemtracking.WorkItem) []RevisionChanges {
+	var result []RevisionChanges
+	previous := map[string]any{}
+	for _, revision := range revisions {
+		fields := map[string]any{}
+		if revision.Fields != nil {
+			fields = *revision.Fields
+		}
+
+		changes := RevisionChanges{
+			ChangedBy:   fields["System.ChangedBy"],
+			ChangedDate: GetChangedDate(&revision),
+		}
+		if revision.Rev != nil {
+			changes.Rev = *revision.Rev
+		}
+
+		keys := slices.Sorted(maps.Keys(fields))
+		for key := range previous {
+			if _, ok := fields[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+
+		for _, key := range keys {
+			if isRevisionServiceField(key) || reflect.DeepEqual(previous[key], fields[key]) {
+				continue
+			}
+			// discussion comment is present only in the revision which added it
+			if key == "System.History" && fields[key] == nil {
+				continue
+			}
+			changes.Changes = append(changes.Changes, FieldChange{Field: key, OldValue: previous[key], NewValue: fields[key]})
+		}
+
+		result = append(result, changes)
+		previous = fields
+	}
+
+	return result
+}
+
+func isRevisionServiceField(field string) bool {
+	return slices.Contains(revisionServiceFields, field) ||
+		strings.HasPrefix(field, "System.AreaLevel") ||
+		strings.HasPrefix(field, "System.IterationLevel")
+}
@@ -0,0 +1,114 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"tasker/ptr"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+)
+
+var (
+	typeTransitionsCache = make(map[string]map[string][]string)
+	typeTransitionsMutex sync.Mutex
+)
+
+// GetTypeTransitions returns allowed state transitions of the work item type (state -> next states)
+// from the process metadata.
+func (api *Client) GetTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	typeTransitionsMutex.Lock()
+	defer typeTransitionsMutex.Unlock()
+
+	if transitions, ok := typeTransitionsCache[workItemType]; ok {
+		return transitions, nil
+	}
+
+	wiType, err := api.GetWorkItemType(ctx, workitemtracking.GetWorkItemTypeArgs{
+		Project: &api.project,
+		Type:    &workItemType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make(map[string][]string)
+	if wiType.Transitions != nil {
+		for from, to := range *wiType.Transitions {
+			for _, transition := range to {
+				if transition.To != nil && *transition.To != from {
+					transitions[from] = append(transitions[from], *transition.To)
+				}
+			}
+		}
+	}
+
+	typeTransitionsCache[workItemType] = transitions
+	return transitions, nil
+}
+
+// FindTransitionPath returns the shortest sequence of states leading from one state to another
+// (without the initial state), nil if the target state is unreachable.
+func FindTransitionPath(transitions map[string][]string, from, to string) []string {
+	if from == to {
+		return []string{}
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for _, next := range transitions[state] {
+			if _, visited := previous[next]; visited {
+				continue
+			}
+			previous[next] = state
+
+			if next == to {
+				var path []string
+				for s := to; s != from; s = previous[s] {
+					path = append([]string{s}, path...)
+				}
+				return path
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// Transition moves the work item into the state setting the fields in the same update.
+func (api *Client) Transition(ctx context.Context, workItemID int, state string, fields map[string]any) (*workitemtracking.WorkItem, error) {
+	operations := []webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/System.State"),
+			Value: state,
+		},
+	}
+
+	for field, value := range fields {
+		operations = append(operations, webapi.JsonPatchOperation{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/" + field),
+			Value: value,
+		})
+	}
+
+	wi, err := api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(workItemID),
+		Project:  &api.project,
+		Document: &operations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transition to %s: %w", state, err)
+	}
+
+	return wi, nil
+}
@@ -0,0 +1,287 @@
+// Package wiql builds Work Item Query Language queries with escaped values.
+package wiql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	WorkItems     = "WorkItems"
+	WorkItemLinks = "WorkItemLinks"
+)
+
+// Link query modes.
+const (
+	ModeMustContain    = "MustContain"
+	ModeMayContain     = "MayContain"
+	ModeDoesNotContain = "DoesNotContain"
+	ModeRecursive      = "Recursive"
+)
+
+// Macro is a value inserted into the query as is, e.g. @Me or @Today.
+type Macro string
+
+const (
+	Me               Macro = "@Me"
+	Today            Macro = "@Today"
+	Project          Macro = "@Project"
+	CurrentIteration Macro = "@CurrentIteration"
+)
+
+// Condition is a part of WHERE clause.
+type Condition interface {
+	build(prefix string) string
+}
+
+type comparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+func (c comparison) build(prefix string) string {
+	return fmt.Sprintf("%s[%s] %s %s", prefix, c.field, c.operator, c.value)
+}
+
+type group struct {
+	operator   string
+	conditions []Condition
+}
+
+func (g group) parts(prefix string) []string {
+	parts := make([]string, 0, len(g.conditions))
+	for _, c := range g.conditions {
+		if c == nil {
+			continue
+		}
+		if part := c.build(prefix); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func (g group) build(prefix string) string {
+	parts := g.parts(prefix)
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	default:
+		return "(" + strings.Join(parts, " "+g.operator+" ") + ")"
+	}
+}
+
+type not struct {
+	condition Condition
+}
+
+func (n not) build(prefix string) string {
+	part := n.condition.build(prefix)
+	if part == "" {
+		return ""
+	}
+	return "NOT (" + part + ")"
+}
+
+type prefixed struct {
+	prefix    string
+	condition Condition
+}
+
+func (p prefixed) build(string) string {
+	return p.condition.build(p.prefix)
+}
+
+func Eq(field string, value any) Condition    { return comparison{field, "=", Value(value)} }
+func NotEq(field string, value any) Condition { return comparison{field, "<>", Value(value)} }
+func Gt(field string, value any) Condition    { return comparison{field, ">", Value(value)} }
+func Ge(field string, value any) Condition    { return comparison{field, ">=", Value(value)} }
+func Lt(field string, value any) Condition    { return comparison{field, "<", Value(value)} }
+func Le(field string, value any) Condition    { return comparison{field, "<=", Value(value)} }
+
+func Contains(field, value string) Condition { return comparison{field, "CONTAINS", Value(value)} }
+
+func ContainsWords(field, value string) Condition {
+	return comparison{field, "CONTAINS WORDS", Value(value)}
+}
+
+// Under matches area or iteration path and all its children.
+func Under(field, path string) Condition { return comparison{field, "UNDER", Value(path)} }
+
+// Ever matches values which the field ever had, e.g. previous assignees.
+func Ever(field string, value any) Condition { return comparison{field, "EVER", Value(value)} }
+
+// In matches any of the values, an empty list matches nothing.
+func In[T any](field string, values ...T) Condition {
+	if len(values) == 0 {
+		return falseCondition{}
+	}
+	return comparison{field, "IN", valuesList(values)}
+}
+
+func NotIn[T any](field string, values ...T) Condition {
+	if len(values) == 0 {
+		return nil
+	}
+	return comparison{field, "NOT IN", valuesList(values)}
+}
+
+// And joins conditions, nil conditions are skipped.
+func And(conditions ...Condition) Condition { return group{"AND", conditions} }
+
+// Or joins conditions, nil conditions are skipped.
+func Or(conditions ...Condition) Condition { return group{"OR", conditions} }
+
+// Not negates the condition, nil and empty conditions are skipped.
+func Not(condition Condition) Condition {
+	if condition == nil {
+		return nil
+	}
+	return not{condition}
+}
+
+// Source applies conditions to the source work item of link query.
+func Source(conditions ...Condition) Condition {
+	return prefixed{"[Source].", And(conditions...)}
+}
+
+// Target applies conditions to the target work item of link query.
+func Target(conditions ...Condition) Condition {
+	return prefixed{"[Target].", And(conditions...)}
+}
+
+// LinkType matches link type of link query.
+func LinkType(linkType string) Condition {
+	return prefixed{"", Eq("System.Links.LinkType", linkType)}
+}
+
+type falseCondition struct{}
+
+func (falseCondition) build(prefix string) string {
+	return prefix + "[System.Id] < 0"
+}
+
+// Value formats the value as WIQL literal, strings are quoted with quotes doubled.
+func Value(value any) string {
+	switch v := value.(type) {
+	case Macro:
+		return string(v)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return "'" + v.Format(time.RFC3339) + "'"
+	default:
+		return Value(fmt.Sprint(v))
+	}
+}
+
+func valuesList[T any](values []T) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = Value(v)
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+type order struct {
+	field      string
+	descending bool
+}
+
+// Query is WIQL query builder.
+type Query struct {
+	fields     []string
+	from       string
+	conditions []Condition
+	orders     []order
+	mode       string
+}
+
+// Select starts the query from WorkItems with the fields.
+func Select(fields ...string) *Query {
+	return &Query{fields: fields, from: WorkItems}
+}
+
+func (q *Query) From(from string) *Query {
+	q.from = from
+	return q
+}
+
+// Where adds conditions joined by AND.
+func (q *Query) Where(conditions ...Condition) *Query {
+	q.conditions = append(q.conditions, conditions...)
+	return q
+}
+
+func (q *Query) OrderBy(field string) *Query {
+	q.orders = append(q.orders, order{field: field})
+	return q
+}
+
+func (q *Query) OrderByDesc(field string) *Query {
+	q.orders = append(q.orders, order{field: field, descending: true})
+	return q
+}
+
+// Mode sets mode of link query.
+func (q *Query) Mode(mode string) *Query {
+	q.mode = mode
+	return q
+}
+
+func (q *Query) String() string {
+	fields := q.fields
+	if len(fields) == 0 {
+		fields = []string{"System.Id"}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("[" + field + "]")
+	}
+	sb.WriteString(" FROM " + q.from)
+
+	where := group{"AND", q.conditions}.parts("")
+	if len(where) > 0 {
+		sb.WriteString(" WHERE " + strings.Join(where, " AND "))
+	}
+
+	for i, o := range q.orders {
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("[" + o.field + "]")
+		if o.descending {
+			sb.WriteString(" DESC")
+		}
+	}
+
+	if q.mode != "" {
+		sb.WriteString(" MODE (" + q.mode + ")")
+	}
+
+	return sb.String()
+}
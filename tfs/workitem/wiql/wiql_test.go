@@ -0,0 +1,129 @@
+package wiql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Select_Default(t *testing.T) {
+	assert.Equal(t, "SELECT [System.Id] FROM WorkItems", Select().String())
+}
+
+func Test_Select_Where(t *testing.T) {
+	query := Select("System.Id", "System.Title").
+		Where(
+			Eq("System.WorkItemType", "Requirement"),
+			ContainsWords("System.Title", "sync"),
+			Eq("System.State", "Active"),
+		)
+
+	assert.Equal(t,
+		"SELECT [System.Id], [System.Title] FROM WorkItems "+
+			"WHERE [System.WorkItemType] = 'Requirement' AND [System.Title] CONTAINS WORDS 'sync' AND [System.State] = 'Active'",
+		query.String())
+}
+
+func Test_Escaping(t *testing.T) {
+	query := Select().Where(Contains("System.Title", "user's 'quoted' title"))
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems WHERE [System.Title] CONTAINS 'user''s ''quoted'' title'",
+		query.String())
+}
+
+func Test_In(t *testing.T) {
+	query := Select().Where(
+		In("System.Id", 1, 2, 3),
+		In("System.State", "New", "Won't fix"),
+		NotIn("System.Tags", "a"),
+	)
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems "+
+			"WHERE [System.Id] IN (1, 2, 3) AND [System.State] IN ('New', 'Won''t fix') AND [System.Tags] NOT IN ('a')",
+		query.String())
+}
+
+func Test_In_Empty(t *testing.T) {
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems WHERE [System.Id] < 0",
+		Select().Where(In[int]("System.Id")).String())
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems",
+		Select().Where(NotIn[string]("System.State")).String())
+}
+
+func Test_Groups(t *testing.T) {
+	query := Select().Where(
+		Or(Eq("System.State", "New"), Eq("System.State", "Active")),
+		Not(And(Eq("System.AssignedTo", Me), Under("System.AreaPath", `NSMS\SMP`))),
+		And(),
+		nil,
+	)
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems "+
+			"WHERE ([System.State] = 'New' OR [System.State] = 'Active') "+
+			`AND NOT (([System.AssignedTo] = @Me AND [System.AreaPath] UNDER 'NSMS\SMP'))`,
+		query.String())
+}
+
+func Test_Not_Empty(t *testing.T) {
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems",
+		Select().Where(Not(NotIn[string]("System.State")), Not(nil), Not(And()), Not(Or(nil))).String())
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems WHERE [System.Id] = 1",
+		Select().Where(And(Eq("System.Id", 1), Not(And(nil)))).String())
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems WHERE NOT ([System.Id] < 0)",
+		Select().Where(Not(In[int]("System.Id"))).String())
+}
+
+func Test_Values(t *testing.T) {
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "5", Value(5))
+	assert.Equal(t, "1.5", Value(1.5))
+	assert.Equal(t, "2.25", Value(float32(2.25)))
+	assert.Equal(t, "true", Value(true))
+	assert.Equal(t, "@Today", Value(Today))
+	assert.Equal(t, "'2024-03-01T10:00:00Z'", Value(date))
+}
+
+func Test_LinkQuery(t *testing.T) {
+	query := Select("System.Id").
+		From(WorkItemLinks).
+		Where(
+			LinkType("System.LinkTypes.Hierarchy-Reverse"),
+			Target(Eq("System.Id", 42)),
+			Source(Eq("System.WorkItemType", "Task"), NotEq("System.State", "Closed")),
+		).
+		Mode(ModeMustContain)
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItemLinks "+
+			"WHERE [System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Reverse' "+
+			"AND [Target].[System.Id] = 42 "+
+			"AND ([Source].[System.WorkItemType] = 'Task' AND [Source].[System.State] <> 'Closed') "+
+			"MODE (MustContain)",
+		query.String())
+}
+
+func Test_OrderBy(t *testing.T) {
+	query := Select("System.Id").
+		Where(Ge("Microsoft.VSTS.Common.Priority", 2)).
+		OrderBy("Microsoft.VSTS.Common.Priority").
+		OrderByDesc("System.ChangedDate")
+
+	assert.Equal(t,
+		"SELECT [System.Id] FROM WorkItems "+
+			"WHERE [Microsoft.VSTS.Common.Priority] >= 2 "+
+			"ORDER BY [Microsoft.VSTS.Common.Priority], [System.ChangedDate] DESC",
+		query.String())
+}
@@ -4,16 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"tasker/ptr"
+	"tasker/tfs/workitem/wiql"
+	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
-	"golang.org/x/exp/slices"
 )
 
+const getWorkItemsBatchSize = 200
+
 type Client struct {
 	workitemtracking.Client
 	project string
@@ -21,8 +29,9 @@ type Client struct {
 }
 
 type Relation struct {
-	URL  string
-	Type string
+	URL     string
+	Type    string
+	Comment string
 }
 
 type Field struct {
@@ -65,6 +74,87 @@ func (api *Client) Update(ctx context.Context, taskID int, title, description st
 	return err
 }
 
+func (api *Client) UpdateRequirement(ctx context.Context, requirementID int, title, description string, estimate, priority float32) error {
+	fields := []webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/System.Title"),
+			Value: title,
+		},
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/System.Description"),
+			Value: description,
+		},
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/Microsoft.VSTS.Common.Priority"),
+			Value: fmt.Sprintf("%v", priority),
+		},
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/Microsoft.VSTS.Scheduling.OriginalEstimate"),
+			Value: estimate,
+		},
+	}
+	_, err := api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(requirementID),
+		Project:  &api.project,
+		Document: &fields,
+	})
+
+	return err
+}
+
+func (api *Client) UpdateField(ctx context.Context, workItemID int, field string, value any) error {
+	fields := []webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/" + field),
+			Value: value,
+		},
+	}
+	_, err := api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(workItemID),
+		Project:  &api.project,
+		Document: &fields,
+	})
+
+	return err
+}
+
+// UpdateFieldsAtRevision updates the fields only if the work item is still at the revision,
+// fields with nil value are removed.
+func (api *Client) UpdateFieldsAtRevision(ctx context.Context, workItemID, rev int, fields map[string]any) (*workitemtracking.WorkItem, error) {
+	operations := []webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Test,
+			Path:  ptr.FromStr("/rev"),
+			Value: rev,
+		},
+	}
+
+	for _, field := range lo.Keys(fields) {
+		value := fields[field]
+		operation := webapi.JsonPatchOperation{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/" + field),
+			Value: value,
+		}
+		if value == nil {
+			operation.Op = &webapi.OperationValues.Remove
+			operation.Value = nil
+		}
+		operations = append(operations, operation)
+	}
+
+	return api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(workItemID),
+		Project:  &api.project,
+		Document: &operations,
+	})
+}
+
 func (api *Client) Get(ctx context.Context, workItemID int) (*workitemtracking.WorkItem, error) {
 	return api.GetWorkItem(ctx, workitemtracking.GetWorkItemArgs{
 		Id: ptr.FromInt(workItemID),
@@ -85,7 +175,190 @@ func (api *Client) GetListExpanded(ctx context.Context, workItemIDs []int) (*[]w
 	})
 }
 
+// GetList returns work items with specified fields (all fields if none specified),
+// requesting them by batches of the maximum size allowed by the API.
+func (api *Client) GetList(ctx context.Context, workItemIDs []int, fields []string) ([]workitemtracking.WorkItem, error) {
+	var result []workitemtracking.WorkItem
+	for _, batch := range lo.Chunk(workItemIDs, getWorkItemsBatchSize) {
+		args := workitemtracking.GetWorkItemsArgs{
+			Ids:     &batch,
+			Project: &api.project,
+		}
+		if len(fields) > 0 {
+			args.Fields = &fields
+		}
+
+		workItems, err := api.GetWorkItems(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *workItems...)
+	}
+
+	return result, nil
+}
+
+// Query runs the WIQL query in the project and team context.
+func (api *Client) Query(ctx context.Context, query *wiql.Query) (*workitemtracking.WorkItemQueryResult, error) {
+	return api.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
+		Wiql: &workitemtracking.Wiql{
+			Query: ptr.FromStr(query.String()),
+		},
+		Project: &api.project,
+		Team:    &api.team,
+	})
+}
+
+// QueryIDs runs the flat WIQL query and returns IDs of found work items.
+func (api *Client) QueryIDs(ctx context.Context, query *wiql.Query) ([]int, error) {
+	return api.QueryIDsByWiql(ctx, query.String())
+}
+
+// QueryIDsByWiql runs the flat WIQL query text and returns IDs of found work items.
+func (api *Client) QueryIDsByWiql(ctx context.Context, query string) ([]int, error) {
+	queryResult, err := api.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
+		Wiql: &workitemtracking.Wiql{
+			Query: &query,
+		},
+		Project: &api.project,
+		Team:    &api.team,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResult.WorkItems == nil {
+		return nil, nil
+	}
+
+	return lo.Map(*queryResult.WorkItems, func(wi workitemtracking.WorkItemReference, _ int) int {
+		return *wi.Id
+	}), nil
+}
+
+// GetChildIDs returns IDs of direct children (by hierarchy links) of the work item.
+func (api *Client) GetChildIDs(ctx context.Context, parentID int) ([]int, error) {
+	queryResult, err := api.Query(ctx, wiql.Select("System.Id").
+		From(wiql.WorkItemLinks).
+		Where(
+			wiql.LinkType("System.LinkTypes.Hierarchy-Reverse"),
+			wiql.Target(wiql.Eq("System.Id", parentID)),
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResult.WorkItemRelations == nil {
+		return nil, nil
+	}
+
+	childIDs := lo.FilterMap(*queryResult.WorkItemRelations, func(link workitemtracking.WorkItemLink, _ int) (int, bool) {
+		if link.Source == nil || link.Target == nil || *link.Target.Id != parentID {
+			return 0, false
+		}
+		return *link.Source.Id, true
+	})
+
+	return lo.Uniq(childIDs), nil
+}
+
+// GetChildLinks returns IDs of direct children by parent ID for the work items.
+func (api *Client) GetChildLinks(ctx context.Context, workItemIDs ...int) (map[int][]int, error) {
+	result := make(map[int][]int)
+	if len(workItemIDs) == 0 {
+		return result, nil
+	}
+
+	queryResult, err := api.Query(ctx, wiql.Select("System.Id").
+		From(wiql.WorkItemLinks).
+		Where(
+			wiql.Source(wiql.In("System.Id", workItemIDs...)),
+			wiql.LinkType("System.LinkTypes.Hierarchy-Forward"),
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResult.WorkItemRelations == nil {
+		return result, nil
+	}
+
+	for _, link := range *queryResult.WorkItemRelations {
+		if link.Source == nil || link.Target == nil {
+			continue
+		}
+		parentID, childID := *link.Source.Id, *link.Target.Id
+		if !lo.Contains(result[parentID], childID) {
+			result[parentID] = append(result[parentID], childID)
+		}
+	}
+
+	return result, nil
+}
+
+// GetChildren returns direct children (by hierarchy links) of the work item.
+func (api *Client) GetChildren(ctx context.Context, parentID int, fields []string) ([]workitemtracking.WorkItem, error) {
+	childIDs, err := api.GetChildIDs(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(childIDs) == 0 {
+		return nil, nil
+	}
+
+	return api.GetList(ctx, childIDs, fields)
+}
+
+// GetDescendantLinks returns IDs of children by parent ID for the whole hierarchy under the work items.
+func (api *Client) GetDescendantLinks(ctx context.Context, workItemIDs ...int) (map[int][]int, error) {
+	result := make(map[int][]int)
+	if len(workItemIDs) == 0 {
+		return result, nil
+	}
+
+	queryResult, err := api.Query(ctx, wiql.Select("System.Id").
+		From(wiql.WorkItemLinks).
+		Where(
+			wiql.Source(wiql.In("System.Id", workItemIDs...)),
+			wiql.LinkType("System.LinkTypes.Hierarchy-Forward"),
+		).
+		Mode(wiql.ModeRecursive))
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResult.WorkItemRelations == nil {
+		return result, nil
+	}
+
+	for _, link := range *queryResult.WorkItemRelations {
+		if link.Source == nil || link.Target == nil {
+			continue
+		}
+		parentID, childID := *link.Source.Id, *link.Target.Id
+		if !lo.Contains(result[parentID], childID) {
+			result[parentID] = append(result[parentID], childID)
+		}
+	}
+
+	return result, nil
+}
+
+// Delete moves the work item into the recycle bin, it can be restored later.
 func (api *Client) Delete(ctx context.Context, workItemID int) error {
+	_, err := api.DeleteWorkItem(ctx, workitemtracking.DeleteWorkItemArgs{
+		Project: &api.project,
+		Destroy: ptr.FromBool(false),
+		Id:      ptr.FromInt(workItemID),
+	})
+
+	return err
+}
+
+// Destroy deletes the work item permanently.
+func (api *Client) Destroy(ctx context.Context, workItemID int) error {
 	_, err := api.DeleteWorkItem(ctx, workitemtracking.DeleteWorkItemArgs{
 		Project: &api.project,
 		Destroy: ptr.FromBool(true),
@@ -95,35 +368,58 @@ func (api *Client) Delete(ctx context.Context, workItemID int) error {
 	return err
 }
 
-func (api *Client) FindUserStory(ctx context.Context, namePattern, iterationPath string) (*workitemtracking.WorkItem, error) {
-	if namePattern == "" {
-		return nil, errors.New("user story name pattern is empty")
-	}
+// Restore moves the deleted work item back from the recycle bin.
+func (api *Client) Restore(ctx context.Context, workItemID int) error {
+	_, err := api.RestoreWorkItem(ctx, workitemtracking.RestoreWorkItemArgs{
+		Payload: &workitemtracking.WorkItemDeleteUpdate{IsDeleted: ptr.FromBool(false)},
+		Id:      ptr.FromInt(workItemID),
+		Project: &api.project,
+	})
 
-	queryResult, err := api.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
-		Wiql: &workitemtracking.Wiql{
-			Query: ptr.FromStr(`
-				SELECT [Id], [Title], [System.AreaPath], [System.IterationPath]
-				FROM WorkItems
-				WHERE [Work Item Type] = 'User Story'
-					AND [System.IterationPath] = '` + iterationPath + `'
-					AND [Title] CONTAINS '` + namePattern + `'
-					AND [State] = 'Active'
-			`),
-		},
+	return err
+}
+
+// GetRecycleBin returns work items of the project in the recycle bin.
+func (api *Client) GetRecycleBin(ctx context.Context) ([]workitemtracking.WorkItemDeleteReference, error) {
+	references, err := api.GetDeletedWorkItemShallowReferences(ctx, workitemtracking.GetDeletedWorkItemShallowReferencesArgs{
 		Project: &api.project,
-		Team:    &api.team,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(*queryResult.WorkItems) > 0 {
-		userStory := &(*queryResult.WorkItems)[0]
-		return api.Get(ctx, *userStory.Id)
+	workItemIDs := lo.FilterMap(*references, func(reference workitemtracking.WorkItemDeleteShallowReference, _ int) (int, bool) {
+		return lo.FromPtr(reference.Id), reference.Id != nil
+	})
+
+	var result []workitemtracking.WorkItemDeleteReference
+	for _, batch := range lo.Chunk(workItemIDs, getWorkItemsBatchSize) {
+		deleted, err := api.GetDeletedWorkItems(ctx, workitemtracking.GetDeletedWorkItemsArgs{
+			Ids:     &batch,
+			Project: &api.project,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *deleted...)
 	}
 
-	return nil, nil
+	return result, nil
+}
+
+func (api *Client) FindUserStory(ctx context.Context, namePattern, iterationPath string) (*workitemtracking.WorkItem, error) {
+	if namePattern == "" {
+		return nil, errors.New("user story name pattern is empty")
+	}
+
+	return api.findFirst(ctx, wiql.Select("System.Id", "System.Title", "System.AreaPath", "System.IterationPath").
+		Where(
+			wiql.Eq("System.WorkItemType", "User Story"),
+			wiql.Eq("System.IterationPath", iterationPath),
+			wiql.Contains("System.Title", namePattern),
+			wiql.Eq("System.State", "Active"),
+		))
 }
 
 func (api *Client) FindRequirement(ctx context.Context, namePattern, iterationPath, state string) (*workitemtracking.WorkItem, error) {
@@ -131,43 +427,37 @@ func (api *Client) FindRequirement(ctx context.Context, namePattern, iterationPa
 		return nil, errors.New("user story name pattern is empty")
 	}
 
-	var iterationFilter string
+	query := wiql.Select("System.Id", "System.Title", "System.AreaPath", "System.IterationPath").
+		Where(
+			wiql.Eq("System.WorkItemType", "Requirement"),
+			wiql.ContainsWords("System.Title", namePattern),
+		)
+
 	if iterationPath != "" {
-		iterationFilter = `AND [System.IterationPath] = '` + iterationPath + `'`
+		query.Where(wiql.Eq("System.IterationPath", iterationPath))
 	}
 
-	var stateFilter string
 	if state != "" {
-		stateFilter = `AND [State] = '` + stateFilter + `'`
+		query.Where(wiql.Eq("System.State", state))
 	}
 
-	queryResult, err := api.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{
-		Wiql: &workitemtracking.Wiql{
-			Query: ptr.FromStr(`
-				SELECT [Id], [Title], [System.AreaPath], [System.IterationPath]
-				FROM WorkItems
-				WHERE [Work Item Type] = 'Requirement'
-					AND [Title] CONTAINS WORDS '` + namePattern + `'
-					` + iterationFilter + `
-					` + stateFilter + `
-			`),
-		},
-		Project: &api.project,
-		Team:    &api.team,
-	})
+	return api.findFirst(ctx, query)
+}
+
+func (api *Client) findFirst(ctx context.Context, query *wiql.Query) (*workitemtracking.WorkItem, error) {
+	ids, err := api.QueryIDs(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(*queryResult.WorkItems) > 0 {
-		userStory := &(*queryResult.WorkItems)[0]
-		return api.Get(ctx, *userStory.Id)
+	if len(ids) > 0 {
+		return api.Get(ctx, ids[0])
 	}
 
 	return nil, nil
 }
 
-func (api *Client) CreateRequirement(ctx context.Context, requirementType, title, description, areaPath, iterationPath string, estimate, priority float32, relations []*Relation, tags []string) (*workitemtracking.WorkItem, error) {
+func (api *Client) CreateRequirement(ctx context.Context, requirementType, title, description, areaPath, iterationPath string, estimate, priority float32, relations []*Relation, tags []string, assignedTo string) (*workitemtracking.WorkItem, error) {
 	fields := []*Field{
 		{
 			Path:  ptr.FromStr("/fields/Microsoft.VSTS.CMMI.RequirementType"),
@@ -183,7 +473,7 @@ func (api *Client) CreateRequirement(ctx context.Context, requirementType, title
 		},
 		{
 			Path:  ptr.FromStr("/fields/System.AssignedTo"),
-			Value: "",
+			Value: assignedTo,
 		},
 	}
 
@@ -272,14 +562,7 @@ func (api *Client) create(ctx context.Context, workitemType, title, description,
 	}
 
 	for _, relation := range relations {
-		documentFields = append(documentFields, webapi.JsonPatchOperation{
-			Op:   &webapi.OperationValues.Add,
-			Path: ptr.FromStr("/relations/-"),
-			Value: workitemtracking.WorkItemRelation{
-				Rel: ptr.FromStr(relation.Type),
-				Url: &relation.URL,
-			},
-		})
+		documentFields = append(documentFields, relation.operation())
 	}
 
 	task, err := api.CreateWorkItem(ctx, workitemtracking.CreateWorkItemArgs{
@@ -294,7 +577,183 @@ func (api *Client) create(ctx context.Context, workitemType, title, description,
 	return task, nil
 }
 
-func (api *Client) Copy(ctx context.Context, sourceWorkItem *workitemtracking.WorkItem, areaPath, iterationPath string, relations []*Relation, tags []string) (*workitemtracking.WorkItem, error) {
+// AddRelations links the work item with the relations.
+func (api *Client) AddRelations(ctx context.Context, workItemID int, relations ...*Relation) (*workitemtracking.WorkItem, error) {
+	operations := lo.Map(relations, func(relation *Relation, _ int) webapi.JsonPatchOperation {
+		return relation.operation()
+	})
+
+	return api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       ptr.FromInt(workItemID),
+		Project:  &api.project,
+		Document: &operations,
+	})
+}
+
+func (r *Relation) operation() webapi.JsonPatchOperation {
+	relation := workitemtracking.WorkItemRelation{
+		Rel: ptr.FromStr(r.Type),
+		Url: &r.URL,
+	}
+	if r.Comment != "" {
+		relation.Attributes = &map[string]any{"comment": r.Comment}
+	}
+
+	return webapi.JsonPatchOperation{
+		Op:    &webapi.OperationValues.Add,
+		Path:  ptr.FromStr("/relations/-"),
+		Value: relation,
+	}
+}
+
+// copyReadOnlyFields are not copied, they are either computed or set by the server.
+var copyReadOnlyFields = []string{
+	"System.Id",
+	"System.Rev",
+	"System.Parent",
+	"System.TeamProject",
+	"System.AreaId",
+	"System.IterationId",
+	"System.NodeName",
+	"System.CreatedDate",
+	"System.CreatedBy",
+	"System.ChangedDate",
+	"System.ChangedBy",
+	"System.AuthorizedDate",
+	"System.AuthorizedAs",
+	"System.RevisedDate",
+	"System.Watermark",
+	"System.PersonId",
+	"System.CommentCount",
+	"System.AttachedFileCount",
+	"System.ExternalLinkCount",
+	"System.HyperLinkCount",
+	"System.RelatedLinkCount",
+	"System.RemoteLinkCount",
+	"System.BoardLane",
+	"Microsoft.VSTS.Common.StateChangeDate",
+	"Microsoft.VSTS.Common.ActivatedDate",
+	"Microsoft.VSTS.Common.ActivatedBy",
+	"Microsoft.VSTS.Common.ResolvedDate",
+	"Microsoft.VSTS.Common.ResolvedBy",
+	"Microsoft.VSTS.Common.ClosedDate",
+	"Microsoft.VSTS.Common.ClosedBy",
+}
+
+// copyStateFields are reset when the copy starts from the initial state.
+var copyStateFields = []string{
+	"System.State",
+	"System.Reason",
+	"Microsoft.VSTS.Common.ResolvedReason",
+	"Microsoft.VSTS.Scheduling.CompletedWork",
+}
+
+var (
+	readOnlyFieldsCache map[string]bool
+	readOnlyFieldsMutex sync.Mutex
+)
+
+type CopyOptions struct {
+	resetState bool
+	fields     map[string]any
+	// onlyFields restrict the source fields copied, all writable fields if empty
+	onlyFields []string
+	// readOnlyFields are marked read-only in the fields metadata of the project
+	readOnlyFields map[string]bool
+}
+
+type CopyOpt func(options *CopyOptions)
+
+// CopyResetState makes the copy start from the initial state with remaining work equal to original estimate.
+func CopyResetState(reset bool) CopyOpt {
+	return func(options *CopyOptions) { options.resetState = reset }
+}
+
+// CopyFields sets the fields of the copy instead of the source values.
+func CopyFields(fields map[string]any) CopyOpt {
+	return func(options *CopyOptions) { options.fields = fields }
+}
+
+// CopyOnlyFields copies only the listed fields of the source besides area, iteration, tags and fields set by CopyFields.
+func CopyOnlyFields(fields ...string) CopyOpt {
+	return func(options *CopyOptions) { options.onlyFields = fields }
+}
+
+func isCopiedField(field string, options *CopyOptions) bool {
+	if len(options.onlyFields) > 0 && !slices.Contains(options.onlyFields, field) {
+		return false
+	}
+	if slices.Contains(copyReadOnlyFields, field) || options.readOnlyFields[field] {
+		return false
+	}
+	if strings.HasPrefix(field, "System.AreaLevel") || strings.HasPrefix(field, "System.IterationLevel") {
+		return false
+	}
+	if strings.Contains(field, "BoardColumn") || strings.HasPrefix(field, "WEF_") {
+		return false
+	}
+	if options.resetState && slices.Contains(copyStateFields, field) {
+		return false
+	}
+	return true
+}
+
+// GetReadOnlyFields returns reference names of the project fields which can't be set by updates.
+func (api *Client) GetReadOnlyFields(ctx context.Context) (map[string]bool, error) {
+	readOnlyFieldsMutex.Lock()
+	defer readOnlyFieldsMutex.Unlock()
+
+	if readOnlyFieldsCache != nil {
+		return readOnlyFieldsCache, nil
+	}
+
+	fields, err := api.GetFields(ctx, workitemtracking.GetFieldsArgs{
+		Project: &api.project,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readOnly := make(map[string]bool)
+	for _, field := range *fields {
+		if field.ReferenceName != nil && field.ReadOnly != nil && *field.ReadOnly {
+			readOnly[*field.ReferenceName] = true
+		}
+	}
+
+	readOnlyFieldsCache = readOnly
+	return readOnly, nil
+}
+
+// getCopiedFieldValue converts field value of source work item to the value accepted by update,
+// identities are replaced by unique names.
+func getCopiedFieldValue(value any) any {
+	if identity, ok := value.(map[string]any); ok {
+		if uniqueName, ok := identity["uniqueName"].(string); ok {
+			return uniqueName
+		}
+		if displayName, ok := identity["displayName"].(string); ok {
+			return displayName
+		}
+		return nil
+	}
+	return value
+}
+
+func (api *Client) Copy(ctx context.Context, sourceWorkItem *workitemtracking.WorkItem, areaPath, iterationPath string, relations []*Relation, tags []string, opts ...CopyOpt) (*workitemtracking.WorkItem, error) {
+	options := &CopyOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+
+	readOnlyFields, err := api.GetReadOnlyFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.readOnlyFields = readOnlyFields
+
 	fields := []webapi.JsonPatchOperation{
 		{
 			Op:    &webapi.OperationValues.Add,
@@ -313,39 +772,53 @@ func (api *Client) Copy(ctx context.Context, sourceWorkItem *workitemtracking.Wo
 		},
 	}
 
-	for key, fieldValue := range *sourceWorkItem.Fields {
-		if strings.Contains(key, "BoardColumn") {
-			continue
-		}
-
-		if value, ok := fieldValue.(string); ok {
-			path := "/fields/" + key
-
-			if slices.ContainsFunc(fields, func(f webapi.JsonPatchOperation) bool {
-				return *f.Path == path
-			}) {
-				continue
-			}
+	for _, key := range slices.Sorted(maps.Keys(options.fields)) {
+		fields = append(fields, webapi.JsonPatchOperation{
+			Op:    &webapi.OperationValues.Add,
+			Path:  ptr.FromStr("/fields/" + key),
+			Value: options.fields[key],
+		})
+	}
 
+	_, remainingWorkSet := options.fields["Microsoft.VSTS.Scheduling.RemainingWork"]
+	if options.resetState && !remainingWorkSet {
+		if estimate, ok := (*sourceWorkItem.Fields)["Microsoft.VSTS.Scheduling.OriginalEstimate"]; ok {
 			fields = append(fields, webapi.JsonPatchOperation{
 				Op:    &webapi.OperationValues.Add,
-				Path:  &path,
-				Value: value,
+				Path:  ptr.FromStr("/fields/Microsoft.VSTS.Scheduling.RemainingWork"),
+				Value: estimate,
 			})
 		}
 	}
 
-	for _, relation := range relations {
+	for _, key := range slices.Sorted(maps.Keys(*sourceWorkItem.Fields)) {
+		if !isCopiedField(key, options) {
+			continue
+		}
+
+		value := getCopiedFieldValue((*sourceWorkItem.Fields)[key])
+		if value == nil {
+			continue
+		}
+
+		path := "/fields/" + key
+		if slices.ContainsFunc(fields, func(f webapi.JsonPatchOperation) bool {
+			return *f.Path == path
+		}) {
+			continue
+		}
+
 		fields = append(fields, webapi.JsonPatchOperation{
-			Op:   &webapi.OperationValues.Add,
-			Path: ptr.FromStr("/relations/-"),
-			Value: workitemtracking.WorkItemRelation{
-				Rel: ptr.FromStr(relation.Type),
-				Url: &relation.URL,
-			},
+			Op:    &webapi.OperationValues.Add,
+			Path:  &path,
+			Value: value,
 		})
 	}
 
+	for _, relation := range relations {
+		fields = append(fields, relation.operation())
+	}
+
 	workItemType := GetType(sourceWorkItem)
 	task, err := api.CreateWorkItem(ctx, workitemtracking.CreateWorkItemArgs{
 		Type:     &workItemType,
@@ -360,7 +833,7 @@ func (api *Client) Copy(ctx context.Context, sourceWorkItem *workitemtracking.Wo
 }
 
 func (api *Client) Assign(ctx context.Context, task *workitemtracking.WorkItem, user string) error {
-	_, err := api.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+	_, err := api.UpdateWorkItem(WithPrevious(ctx, task), workitemtracking.UpdateWorkItemArgs{
 		Id:      task.Id,
 		Project: &api.project,
 		Document: &[]webapi.JsonPatchOperation{
@@ -413,6 +886,11 @@ func GetTitle(w *workitemtracking.WorkItem) string {
 	return ""
 }
 
+func GetDescription(w *workitemtracking.WorkItem) string {
+	description, _ := (*w.Fields)["System.Description"].(string)
+	return description
+}
+
 func GetIterationPath(w *workitemtracking.WorkItem) string {
 	iterationPath, ok := (*w.Fields)["System.IterationPath"]
 	if ok {
@@ -460,3 +938,104 @@ func GetTags(w *workitemtracking.WorkItem) []string {
 	}
 	return nil
 }
+
+func GetState(w *workitemtracking.WorkItem) string {
+	state, ok := (*w.Fields)["System.State"]
+	if ok {
+		stateStr, ok := state.(string)
+		if ok {
+			return stateStr
+		}
+	}
+	return ""
+}
+
+func GetPriority(w *workitemtracking.WorkItem) float32 {
+	return getFloatField(w, "Microsoft.VSTS.Common.Priority")
+}
+
+func GetCompletedWork(w *workitemtracking.WorkItem) float32 {
+	return getFloatField(w, "Microsoft.VSTS.Scheduling.CompletedWork")
+}
+
+func GetRemainingWork(w *workitemtracking.WorkItem) float32 {
+	return getFloatField(w, "Microsoft.VSTS.Scheduling.RemainingWork")
+}
+
+func GetOriginalEstimate(w *workitemtracking.WorkItem) float32 {
+	return getFloatField(w, "Microsoft.VSTS.Scheduling.OriginalEstimate")
+}
+
+// GetCompletedWork64 returns Completed Work without float32 rounding, for arithmetic on hours.
+func GetCompletedWork64(w *workitemtracking.WorkItem) float64 {
+	return getFloat64Field(w, "Microsoft.VSTS.Scheduling.CompletedWork")
+}
+
+// GetRemainingWork64 returns Remaining Work without float32 rounding, for arithmetic on hours.
+func GetRemainingWork64(w *workitemtracking.WorkItem) float64 {
+	return getFloat64Field(w, "Microsoft.VSTS.Scheduling.RemainingWork")
+}
+
+func getFloatField(w *workitemtracking.WorkItem, name string) float32 {
+	value, ok := (*w.Fields)[name]
+	if ok {
+		switch v := value.(type) {
+		case float64:
+			return float32(v)
+		case float32:
+			return v
+		case int:
+			return float32(v)
+		case string:
+			parsed, err := strconv.ParseFloat(v, 32)
+			if err == nil {
+				return float32(parsed)
+			}
+		}
+	}
+	return 0
+}
+
+func getFloat64Field(w *workitemtracking.WorkItem, name string) float64 {
+	value, ok := (*w.Fields)[name]
+	if ok {
+		switch v := value.(type) {
+		case float64:
+			return v
+		case float32:
+			return float64(v)
+		case int:
+			return float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err == nil {
+				return parsed
+			}
+		}
+	}
+	return 0
+}
+
+func GetCreatedDate(w *workitemtracking.WorkItem) time.Time {
+	return getTimeField(w, "System.CreatedDate")
+}
+
+func GetChangedDate(w *workitemtracking.WorkItem) time.Time {
+	return getTimeField(w, "System.ChangedDate")
+}
+
+func getTimeField(w *workitemtracking.WorkItem, name string) time.Time {
+	value, ok := (*w.Fields)[name]
+	if ok {
+		switch v := value.(type) {
+		case time.Time:
+			return v
+		case string:
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err == nil {
+				return parsed
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,60 @@
+package workitem
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsCopiedField(t *testing.T) {
+	tests := []struct {
+		field   string
+		options CopyOptions
+		want    bool
+	}{
+		{field: "System.Title", want: true},
+		{field: "System.AssignedTo", want: true},
+		{field: "Microsoft.VSTS.Scheduling.OriginalEstimate", want: true},
+		{field: "System.State", want: true},
+		{field: "System.State", options: CopyOptions{resetState: true}, want: false},
+		{field: "Microsoft.VSTS.Scheduling.CompletedWork", options: CopyOptions{resetState: true}, want: false},
+		{field: "System.Id", want: false},
+		{field: "System.Rev", want: false},
+		{field: "System.Parent", want: false},
+		{field: "System.CreatedDate", want: false},
+		{field: "System.AttachedFileCount", want: false},
+		{field: "System.ExternalLinkCount", want: false},
+		{field: "System.HyperLinkCount", want: false},
+		{field: "System.RelatedLinkCount", want: false},
+		{field: "System.RemoteLinkCount", want: false},
+		{field: "System.CommentCount", want: false},
+		{field: "Microsoft.VSTS.Common.ClosedDate", want: false},
+		{field: "Microsoft.VSTS.Common.ClosedBy", want: false},
+		{field: "Microsoft.VSTS.Common.ActivatedBy", want: false},
+		{field: "System.AreaLevel2", want: false},
+		{field: "System.IterationLevel3", want: false},
+		{field: "WEF_6CB513B6E70E43499D9FC94E5BBFB784_Kanban.Column", want: false},
+		{field: "System.BoardColumnDone", want: false},
+		{field: "Custom.Computed", options: CopyOptions{readOnlyFields: map[string]bool{"Custom.Computed": true}}, want: false},
+		{field: "System.Title", options: CopyOptions{onlyFields: []string{"System.Title"}}, want: true},
+		{field: "System.Description", options: CopyOptions{onlyFields: []string{"System.Title"}}, want: false},
+		{field: "Custom.Editable", options: CopyOptions{readOnlyFields: map[string]bool{"Custom.Computed": true}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.want, isCopiedField(tt.field, &tt.options))
+		})
+	}
+}
+
+func Test_GetCompletedWork64(t *testing.T) {
+	w := &workitemtracking.WorkItem{Fields: &map[string]any{
+		"Microsoft.VSTS.Scheduling.CompletedWork": 0.1,
+		"Microsoft.VSTS.Scheduling.RemainingWork": "2.3",
+	}}
+
+	assert.Equal(t, 0.1, GetCompletedWork64(w))
+	assert.Equal(t, 2.3, GetRemainingWork64(w))
+}
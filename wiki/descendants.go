@@ -0,0 +1,169 @@
+package wiki
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	goconfluence "github.com/virtomize/confluence-go-api"
+)
+
+const pagesRequestLimit = 100
+
+type DescendantPagesOptions struct {
+	depth   int
+	labels  []string
+	cql     string
+	exclude []string
+}
+
+type DescendantPagesOpt func(options *DescendantPagesOptions)
+
+// DescendantsDepth limits the depth of the walk, 1 means direct children only, 0 means unlimited.
+func DescendantsDepth(depth int) DescendantPagesOpt {
+	return func(options *DescendantPagesOptions) { options.depth = depth }
+}
+
+// DescendantsLabels keeps only pages having any of the labels.
+func DescendantsLabels(labels ...string) DescendantPagesOpt {
+	return func(options *DescendantPagesOptions) { options.labels = append(options.labels, labels...) }
+}
+
+// DescendantsCQL keeps only pages matching the CQL query.
+func DescendantsCQL(cql string) DescendantPagesOpt {
+	return func(options *DescendantPagesOptions) { options.cql = cql }
+}
+
+// DescendantsExclude skips the pages and their descendants.
+func DescendantsExclude(pageIDs ...string) DescendantPagesOpt {
+	return func(options *DescendantPagesOptions) { options.exclude = append(options.exclude, pageIDs...) }
+}
+
+// GetDescendantPages walks the page tree under pageID and returns all found pages
+// in breadth-first order, requesting children with full pagination.
+func (a *API) GetDescendantPages(pageID string, opts ...DescendantPagesOpt) ([]goconfluence.Results, error) {
+	options := &DescendantPagesOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+
+	var pages []goconfluence.Results
+	level := []string{pageID}
+	for depth := 1; len(level) > 0 && (options.depth <= 0 || depth <= options.depth); depth++ {
+		var nextLevel []string
+		for _, id := range level {
+			children, err := a.GetAllChildPages(id)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, child := range children {
+				if lo.Contains(options.exclude, child.ID) {
+					continue
+				}
+				pages = append(pages, child)
+				nextLevel = append(nextLevel, child.ID)
+			}
+		}
+		level = nextLevel
+	}
+
+	if len(options.labels) == 0 && options.cql == "" {
+		return pages, nil
+	}
+
+	matched, err := a.searchDescendantIDs(pageID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(pages, func(page goconfluence.Results, _ int) bool {
+		return lo.Contains(matched, page.ID)
+	}), nil
+}
+
+// GetAllChildPages returns direct children of the page requesting all result pages.
+func (a *API) GetAllChildPages(pageID string) ([]goconfluence.Results, error) {
+	ep, err := url.ParseRequestURI(a.endPoint.String() + "/content/" + pageID + "/child/page")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []goconfluence.Results
+	start := 0
+	for {
+		ep.RawQuery = url.Values{
+			"start": []string{strconv.Itoa(start)},
+			"limit": []string{strconv.Itoa(pagesRequestLimit)},
+		}.Encode()
+
+		s, err := a.SendSearchRequest(ep, "GET")
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, s.Results...)
+		if len(s.Results) == 0 || len(s.Results) < getResultsLimit(s) {
+			break
+		}
+		start += len(s.Results)
+	}
+
+	return results, nil
+}
+
+var cqlStringReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// escapeCQLString escapes the value to be placed into the double-quoted CQL string.
+func escapeCQLString(value string) string {
+	return cqlStringReplacer.Replace(value)
+}
+
+func (a *API) searchDescendantIDs(pageID string, options *DescendantPagesOptions) ([]string, error) {
+	query := "type=page AND ancestor=" + pageID
+	if options.cql != "" {
+		query += " AND (" + options.cql + ")"
+	}
+
+	if len(options.labels) > 0 {
+		labelsFilter := lo.Map(options.labels, func(label string, _ int) string {
+			return "label=\"" + escapeCQLString(label) + "\""
+		})
+		query += " AND (" + strings.Join(labelsFilter, " OR ") + ")"
+	}
+
+	var ids []string
+	start := 0
+	for {
+		s, err := a.SearchContent(goconfluence.SearchQuery{
+			CQL:   query,
+			Start: start,
+			Limit: pagesRequestLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range s.Results {
+			ids = append(ids, result.ID)
+		}
+
+		if len(s.Results) == 0 || len(s.Results) < getResultsLimit(s) {
+			break
+		}
+		start += len(s.Results)
+	}
+
+	return ids, nil
+}
+
+// getResultsLimit returns the page size actually applied by the server, which can be lower than requested.
+func getResultsLimit(s *goconfluence.Search) int {
+	if s.Limit > 0 {
+		return s.Limit
+	}
+	return pagesRequestLimit
+}
@@ -0,0 +1,128 @@
+package wiki
+
+import (
+	"tasker/journal"
+
+	goconfluence "github.com/virtomize/confluence-go-api"
+)
+
+// UpdateContent updates the page saving its previous version into the journal.
+func (a *API) UpdateContent(c *goconfluence.Content) (*goconfluence.Content, error) {
+	content, err := a.API.UpdateContent(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Version != nil && c.Version.Number > 1 {
+		journal.Add(journal.Change{
+			Operation:   journal.OperationPage,
+			PageID:      c.ID,
+			PageVersion: c.Version.Number - 1,
+		})
+	}
+
+	return content, nil
+}
+
+// CreateContent creates the page saving it into the journal.
+func (a *API) CreateContent(c *goconfluence.Content) (*goconfluence.Content, error) {
+	content, err := a.API.CreateContent(c)
+	if err != nil {
+		return nil, err
+	}
+
+	journal.Add(journal.Change{
+		Operation: journal.OperationCreate,
+		PageID:    content.ID,
+	})
+	return content, nil
+}
+
+// DelContent moves the page into the trash saving it into the journal.
+func (a *API) DelContent(id string) (*goconfluence.Content, error) {
+	content, err := a.API.DelContent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	journal.Add(journal.Change{
+		Operation: journal.OperationDelete,
+		PageID:    id,
+	})
+	return content, nil
+}
+
+// RestorePage restores the page from the trash.
+func (a *API) RestorePage(pageID string) error {
+	trashed, err := a.GetContentByID(pageID, goconfluence.ContentQuery{
+		Status: "trashed",
+		Expand: []string{"space", "version"},
+	})
+	if err != nil {
+		return err
+	}
+
+	// trashed content is restored by the new version with current status, other fields are not changed
+	_, err = a.API.UpdateContent(&goconfluence.Content{
+		ID:     trashed.ID,
+		Type:   trashed.Type,
+		Status: "current",
+		Title:  trashed.Title,
+		Space: &goconfluence.Space{
+			Key: trashed.Space.Key,
+		},
+		Version: &goconfluence.Version{
+			Number: trashed.Version.Number + 1,
+		},
+	})
+	return err
+}
+
+// RestorePageVersion makes the content of the page version the latest version of the page.
+func (a *API) RestorePageVersion(pageID string, version int) error {
+	current, err := a.GetContentByID(pageID, goconfluence.ContentQuery{
+		Expand: []string{"space", "version"},
+	})
+	if err != nil {
+		return err
+	}
+
+	previous, err := a.GetContentByID(pageID, goconfluence.ContentQuery{
+		Status:  "historical",
+		Version: version,
+		Expand:  []string{"body.storage"},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = a.UpdateContent(&goconfluence.Content{
+		ID:    current.ID,
+		Type:  current.Type,
+		Title: previous.Title,
+		Body: goconfluence.Body{
+			Storage: goconfluence.Storage{
+				Value:          previous.Body.Storage.Value,
+				Representation: "storage",
+			},
+		},
+		Space: &goconfluence.Space{
+			Key: current.Space.Key,
+		},
+		Version: &goconfluence.Version{
+			Number: current.Version.Number + 1,
+		},
+	})
+	return err
+}
+
+// GetPageVersion returns the latest version number of the page.
+func (a *API) GetPageVersion(pageID string) (int, error) {
+	page, err := a.GetContentByID(pageID, goconfluence.ContentQuery{
+		Expand: []string{"version"},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return page.Version.Number, nil
+}
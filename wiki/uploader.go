@@ -1,20 +1,17 @@
 package wiki
 
 import (
+	"html"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 func UploadContent(api *API, pageID, content, contentType string, opts ...UploadOption) error {
 	if IsMarkdownContentType(contentType) {
-		content = `` +
-			`<ac:structured-macro ac:name="markdown" ac:schema-version="1" ac:macro-id="` + uuid.NewString() + `">
-				<ac:parameter ac:name="allowHtml">true</ac:parameter>
-  				<ac:parameter ac:name="headerLinks">true</ac:parameter>
-				<ac:parameter ac:name="atlassian-macro-output-type">BLOCK</ac:parameter>
-				<ac:plain-text-body><![CDATA[` + content + `]]></ac:plain-text-body>
-			</ac:structured-macro>`
+		content = MarkdownMacro(content)
 		contentType = "storage"
 	}
 
@@ -37,6 +34,40 @@ func UploadContent(api *API, pageID, content, contentType string, opts ...Upload
 	return api.UploadContent(pageID, content, contentType)
 }
 
+// MarkdownMacro wraps markdown content into storage format markdown macro.
+func MarkdownMacro(content string) string {
+	return `` +
+		`<ac:structured-macro ac:name="markdown" ac:schema-version="1" ac:macro-id="` + uuid.NewString() + `">
+				<ac:parameter ac:name="allowHtml">true</ac:parameter>
+  				<ac:parameter ac:name="headerLinks">true</ac:parameter>
+				<ac:parameter ac:name="atlassian-macro-output-type">BLOCK</ac:parameter>
+				<ac:plain-text-body><![CDATA[` + content + `]]></ac:plain-text-body>
+			</ac:structured-macro>`
+}
+
+// ChartMacro builds storage format chart macro of the type (line, bar, pie etc.) from the table,
+// the first row is a header, the first column contains categories and other columns are series.
+func ChartMacro(title, chartType string, data [][]string) string {
+	var table strings.Builder
+	for i, row := range data {
+		cell := lo.Ternary(i == 0, "th", "td")
+		table.WriteString("<tr>")
+		for _, value := range row {
+			table.WriteString("<" + cell + ">" + html.EscapeString(value) + "</" + cell + ">")
+		}
+		table.WriteString("</tr>")
+	}
+
+	return `` +
+		`<ac:structured-macro ac:name="chart" ac:schema-version="1" ac:macro-id="` + uuid.NewString() + `">
+				<ac:parameter ac:name="title">` + html.EscapeString(title) + `</ac:parameter>
+				<ac:parameter ac:name="type">` + chartType + `</ac:parameter>
+				<ac:parameter ac:name="dataOrientation">vertical</ac:parameter>
+				<ac:parameter ac:name="width">800</ac:parameter>
+				<ac:rich-text-body><table><tbody>` + table.String() + `</tbody></table></ac:rich-text-body>
+			</ac:structured-macro>`
+}
+
 func IsMarkdownContentType(contentType string) bool {
 	return contentType == "md" || contentType == "markdown"
 }